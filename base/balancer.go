@@ -0,0 +1,526 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LoadBalancingStrategy selects how the load balancer picks an endpoint.
+type LoadBalancingStrategy int
+
+const (
+	// LoadBalancingRoundRobin sends requests to the endpoints in turn.
+	LoadBalancingRoundRobin LoadBalancingStrategy = iota
+	// LoadBalancingLeastInFlight sends requests to the endpoint with the fewest requests in flight.
+	LoadBalancingLeastInFlight
+	// LoadBalancingPowerOfTwo picks two endpoints at random and sends the request to the
+	// one with fewer requests in flight.
+	LoadBalancingPowerOfTwo
+)
+
+// String returns the string representation of the load balancing strategy.
+func (s LoadBalancingStrategy) String() string {
+	switch s {
+	case LoadBalancingRoundRobin:
+		return "round_robin"
+	case LoadBalancingLeastInFlight:
+		return "least_in_flight"
+	case LoadBalancingPowerOfTwo:
+		return "power_of_two"
+	default:
+		return "unknown"
+	}
+}
+
+// Load balancer defaults.
+const (
+	// Default interval between Resolver lookups.
+	defaultRefreshInterval = 30 * time.Second
+
+	// Default time limit for a background Resolver lookup.
+	defaultResolveTimeout = 5 * time.Second
+
+	// Default outlier detection settings.
+	defaultOutlierConsecutiveFailures = 5
+	defaultOutlierEjectionDuration    = 30 * time.Second
+	defaultOutlierMaxEjectionPercent  = 0.5
+)
+
+// LoadBalancerConfig holds client-side load balancing configuration.
+// Every attempt is sent to one endpoint, replacing the scheme, host and path prefix
+// of the client BaseURL, which still names the service in logs and metrics.
+type LoadBalancerConfig struct {
+	// Endpoints are the base URLs of the service instances, e.g. "http://10.0.0.5:8080".
+	// Ignored if Resolver is set.
+	Endpoints []string
+
+	// Resolver discovers the endpoints. If nil, Endpoints is used.
+	Resolver Resolver
+
+	// RefreshInterval is how often the Resolver is polled (default: 30s).
+	// Endpoints are kept if a lookup fails or returns none.
+	RefreshInterval time.Duration
+
+	// Strategy selects how an endpoint is picked (default: LoadBalancingRoundRobin).
+	Strategy LoadBalancingStrategy
+
+	// CircuitBreaker configures a circuit breaker for every endpoint. Endpoints with an
+	// open circuit are skipped. If nil, endpoints have no circuit breaker.
+	CircuitBreaker *CircuitBreakerConfig
+
+	// OutlierDetection ejects failing endpoints for a while. If nil, endpoints are never ejected.
+	OutlierDetection *OutlierDetectionConfig
+}
+
+// OutlierDetectionConfig holds outlier ejection configuration.
+type OutlierDetectionConfig struct {
+	// ConsecutiveFailures is the number of failures in a row that ejects an endpoint (default: 5).
+	ConsecutiveFailures int
+
+	// EjectionDuration is how long an endpoint is ejected the first time (default: 30s).
+	// It grows with every ejection until the endpoint succeeds again.
+	EjectionDuration time.Duration
+
+	// MaxEjectionPercent is the largest fraction, between 0 and 1, of the endpoints
+	// that can be ejected at once (default: 0.5). At least one endpoint can always be ejected.
+	MaxEjectionPercent float64
+}
+
+// Validate validates the load balancer configuration.
+func (c *LoadBalancerConfig) Validate() error {
+	if c.Resolver == nil {
+		if len(c.Endpoints) == 0 {
+			return fmt.Errorf("endpoints or resolver is required")
+		}
+		for _, endpoint := range c.Endpoints {
+			if _, err := parseEndpoint(endpoint); err != nil {
+				return err
+			}
+		}
+	}
+
+	if c.RefreshInterval < 0 {
+		return fmt.Errorf("refresh interval cannot be negative")
+	}
+
+	switch c.Strategy {
+	case LoadBalancingRoundRobin, LoadBalancingLeastInFlight, LoadBalancingPowerOfTwo:
+	default:
+		return fmt.Errorf("unknown strategy %d", c.Strategy)
+	}
+
+	if c.CircuitBreaker != nil {
+		if err := c.CircuitBreaker.Validate(); err != nil {
+			return fmt.Errorf("circuit breaker config: %w", err)
+		}
+	}
+
+	if c.OutlierDetection != nil {
+		if err := c.OutlierDetection.Validate(); err != nil {
+			return fmt.Errorf("outlier detection config: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Validate validates the outlier detection configuration.
+func (c *OutlierDetectionConfig) Validate() error {
+	if c.ConsecutiveFailures < 0 {
+		return fmt.Errorf("consecutive failures cannot be negative")
+	}
+
+	if c.EjectionDuration < 0 {
+		return fmt.Errorf("ejection duration cannot be negative")
+	}
+
+	if c.MaxEjectionPercent < 0 || c.MaxEjectionPercent > 1.0 {
+		return fmt.Errorf("max ejection percent must be between 0 and 1")
+	}
+
+	return nil
+}
+
+// parseEndpoint parses an endpoint base URL.
+func parseEndpoint(endpoint string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint %q: scheme and host are required", endpoint)
+	}
+	return u, nil
+}
+
+// EndpointStats holds statistics for one load balancer endpoint.
+type EndpointStats struct {
+	URL string
+
+	// InFlight is the number of requests in flight.
+	InFlight int
+	// Requests and Failures count the completed requests since the endpoint was added.
+	Requests int64
+	Failures int64
+
+	// CircuitState is the endpoint circuit breaker state, or CircuitClosed without a circuit breaker.
+	CircuitState CircuitState
+	// EjectedUntil is when the outlier ejection ends, or zero if the endpoint is not ejected.
+	EjectedUntil time.Time
+}
+
+// endpoint is one instance of a load balanced service.
+type endpoint struct {
+	raw     string
+	url     *url.URL
+	breaker *CircuitBreaker
+
+	// Guarded by balancer.mu.
+	inFlight            int
+	requests            int64
+	failures            int64
+	consecutiveFailures int
+	ejections           int
+	ejectedUntil        time.Time
+}
+
+// balancer spreads attempts across the endpoints of a service.
+type balancer struct {
+	name            string
+	base            *url.URL
+	strategy        LoadBalancingStrategy
+	breakerConfig   *CircuitBreakerConfig
+	outlier         *OutlierDetectionConfig
+	resolver        Resolver
+	refreshInterval time.Duration
+
+	mu          sync.Mutex
+	endpoints   []*endpoint
+	next        int
+	lastRefresh time.Time
+	refreshing  bool
+}
+
+// newBalancer creates a balancer for the service with the given base URL.
+func newBalancer(cfg *LoadBalancerConfig, name string, base *url.URL) *balancer {
+	b := &balancer{
+		name:            name,
+		base:            base,
+		strategy:        cfg.Strategy,
+		breakerConfig:   cfg.CircuitBreaker,
+		resolver:        cfg.Resolver,
+		refreshInterval: cfg.RefreshInterval,
+	}
+	if b.refreshInterval <= 0 {
+		b.refreshInterval = defaultRefreshInterval
+	}
+
+	if cfg.OutlierDetection != nil {
+		outlier := *cfg.OutlierDetection
+		if outlier.ConsecutiveFailures <= 0 {
+			outlier.ConsecutiveFailures = defaultOutlierConsecutiveFailures
+		}
+		if outlier.EjectionDuration <= 0 {
+			outlier.EjectionDuration = defaultOutlierEjectionDuration
+		}
+		if outlier.MaxEjectionPercent <= 0 {
+			outlier.MaxEjectionPercent = defaultOutlierMaxEjectionPercent
+		}
+		b.outlier = &outlier
+	}
+
+	if b.resolver == nil {
+		b.setEndpoints(cfg.Endpoints)
+	}
+
+	return b
+}
+
+// setEndpoints replaces the endpoints, keeping the state of those still present.
+func (b *balancer) setEndpoints(raw []string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	existing := make(map[string]*endpoint, len(b.endpoints))
+	for _, ep := range b.endpoints {
+		existing[ep.raw] = ep
+	}
+
+	endpoints := make([]*endpoint, 0, len(raw))
+	for _, r := range raw {
+		if ep, ok := existing[r]; ok {
+			endpoints = append(endpoints, ep)
+			delete(existing, r)
+			continue
+		}
+
+		u, err := parseEndpoint(r)
+		if err != nil {
+			continue
+		}
+		ep := &endpoint{raw: r, url: u}
+		if b.breakerConfig != nil {
+			cbConfig := *b.breakerConfig
+			cbConfig.Name = b.name + "/" + u.Host
+			ep.breaker = NewCircuitBreaker(&cbConfig)
+		}
+		endpoints = append(endpoints, ep)
+	}
+
+	b.endpoints = endpoints
+}
+
+// refresh looks up the endpoints with the resolver.
+func (b *balancer) refresh(ctx context.Context) error {
+	endpoints, err := b.resolver.Resolve(ctx)
+
+	b.mu.Lock()
+	b.lastRefresh = time.Now()
+	b.refreshing = false
+	b.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	if len(endpoints) > 0 {
+		b.setEndpoints(endpoints)
+	}
+	return nil
+}
+
+// maybeRefresh resolves the endpoints if they are due for a refresh. Lookups run in
+// the background, except while there are no endpoints yet.
+func (b *balancer) maybeRefresh(ctx context.Context) {
+	if b.resolver == nil {
+		return
+	}
+
+	b.mu.Lock()
+	if len(b.endpoints) == 0 {
+		b.mu.Unlock()
+		_ = b.refresh(ctx)
+		return
+	}
+	if b.refreshing || time.Since(b.lastRefresh) < b.refreshInterval {
+		b.mu.Unlock()
+		return
+	}
+	b.refreshing = true
+	b.mu.Unlock()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultResolveTimeout)
+		defer cancel()
+		_ = b.refresh(ctx)
+	}()
+}
+
+// pick selects an endpoint for an attempt and marks a request in flight on it.
+// It returns nil if every endpoint is ejected or has an open circuit.
+func (b *balancer) pick(ctx context.Context) *endpoint {
+	b.maybeRefresh(ctx)
+
+	b.mu.Lock()
+	now := time.Now()
+	candidates := slices.DeleteFunc(b.candidatesLocked(), func(ep *endpoint) bool {
+		return now.Before(ep.ejectedUntil)
+	})
+	b.mu.Unlock()
+
+	// Circuit breakers are consulted without b.mu held, since their hooks may read the stats.
+	for _, ep := range candidates {
+		if ep.breaker != nil && !ep.breaker.Allow() {
+			continue
+		}
+		b.mu.Lock()
+		ep.inFlight++
+		b.mu.Unlock()
+		return ep
+	}
+	return nil
+}
+
+// candidatesLocked returns the endpoints in the order the strategy prefers them.
+// It must be called with b.mu held.
+func (b *balancer) candidatesLocked() []*endpoint {
+	n := len(b.endpoints)
+	if n == 0 {
+		return nil
+	}
+
+	candidates := make([]*endpoint, 0, n)
+	if b.strategy == LoadBalancingPowerOfTwo {
+		// Cryptographic randomness is not required to pick an endpoint.
+		for _, i := range rand.Perm(n) { // #nosec G404
+			candidates = append(candidates, b.endpoints[i])
+		}
+		if n > 1 && candidates[1].inFlight < candidates[0].inFlight {
+			candidates[0], candidates[1] = candidates[1], candidates[0]
+		}
+		return candidates
+	}
+
+	start := b.next % n
+	b.next++
+	for i := 0; i < n; i++ {
+		candidates = append(candidates, b.endpoints[(start+i)%n])
+	}
+
+	if b.strategy == LoadBalancingLeastInFlight {
+		// The rotation spreads ties across the endpoints.
+		slices.SortStableFunc(candidates, func(x, y *endpoint) int {
+			return x.inFlight - y.inFlight
+		})
+	}
+
+	return candidates
+}
+
+// done records the outcome of an attempt sent to the endpoint and ends its request in flight.
+// Ignored attempts, such as those cancelled by the caller, only end the request in flight
+// and give back the half-open probe slot they took.
+func (b *balancer) done(ep *endpoint, success, ignored bool, duration time.Duration) {
+	b.record(ep, success, ignored, duration)
+	b.end(ep)
+}
+
+// end ends a request in flight on the endpoint.
+func (b *balancer) end(ep *endpoint) {
+	b.mu.Lock()
+	ep.inFlight--
+	b.mu.Unlock()
+}
+
+// record records the outcome of an attempt sent to the endpoint, without ending its
+// request in flight.
+func (b *balancer) record(ep *endpoint, success, ignored bool, duration time.Duration) {
+	if ep.breaker != nil {
+		if ignored {
+			ep.breaker.Release()
+		} else {
+			ep.breaker.RecordResult(success, duration)
+		}
+	}
+	if ignored {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ep.requests++
+	if success {
+		ep.consecutiveFailures = 0
+		ep.ejections = 0
+		return
+	}
+
+	ep.failures++
+	ep.consecutiveFailures++
+	if b.outlier != nil && ep.consecutiveFailures >= b.outlier.ConsecutiveFailures {
+		b.ejectLocked(ep)
+	}
+}
+
+// ejectLocked ejects the endpoint unless too many endpoints are ejected already.
+// It must be called with b.mu held.
+func (b *balancer) ejectLocked(ep *endpoint) {
+	now := time.Now()
+	ejected := 0
+	for _, other := range b.endpoints {
+		if now.Before(other.ejectedUntil) {
+			ejected++
+		}
+	}
+
+	maxEjected := max(int(b.outlier.MaxEjectionPercent*float64(len(b.endpoints))), 1)
+	if ejected >= maxEjected {
+		return
+	}
+
+	ep.ejections++
+	ep.consecutiveFailures = 0
+	ep.ejectedUntil = now.Add(time.Duration(ep.ejections) * b.outlier.EjectionDuration)
+}
+
+// stats returns the statistics of every endpoint.
+func (b *balancer) stats() []EndpointStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	stats := make([]EndpointStats, 0, len(b.endpoints))
+	for _, ep := range b.endpoints {
+		s := EndpointStats{
+			URL:      ep.raw,
+			InFlight: ep.inFlight,
+			Requests: ep.requests,
+			Failures: ep.failures,
+		}
+		if ep.breaker != nil {
+			s.CircuitState = ep.breaker.State()
+		}
+		if now.Before(ep.ejectedUntil) {
+			s.EjectedUntil = ep.ejectedUntil
+		}
+		stats = append(stats, s)
+	}
+	return stats
+}
+
+// endpointURL returns u with the scheme, host and path prefix of base replaced by those
+// of the endpoint. URLs outside base are returned unchanged.
+func endpointURL(u, base, ep *url.URL) *url.URL {
+	if u.Scheme != base.Scheme || u.Host != base.Host || !strings.HasPrefix(u.Path, base.Path) {
+		return u
+	}
+
+	rewritten := *u
+	rewritten.Scheme = ep.Scheme
+	rewritten.Host = ep.Host
+	rewritten.Path = ep.Path + strings.TrimPrefix(u.Path, base.Path)
+	// The escaped path is rebuilt too, so escaped path parameters such as "a%2Fb" are kept.
+	rewritten.RawPath = ""
+	if escaped, ok := strings.CutPrefix(u.EscapedPath(), base.EscapedPath()); ok {
+		rewritten.RawPath = ep.EscapedPath() + escaped
+	}
+	return &rewritten
+}
+
+// executeBalanced sends one attempt to an endpoint picked by the load balancer.
+func (c *Client) executeBalanced(ctx context.Context, req *http.Request) (*Response, error) {
+	ep := c.balancer.pick(ctx)
+	if ep == nil {
+		return nil, ErrNoHealthyEndpoint(c.serviceName)
+	}
+
+	req.URL = endpointURL(req.URL, c.balancer.base, ep.url)
+	req.Host = ""
+
+	start := time.Now()
+	resp, err := c.send(req)
+	duration := time.Since(start)
+
+	switch {
+	case err == nil && resp.stream != nil:
+		// A streamed response stays in flight on the endpoint until its body is closed,
+		// but its outcome is recorded now so a half-open probe does not wait for the body.
+		c.balancer.record(ep, resp.StatusCode < 500, false, duration)
+		resp.onClose(func() { c.balancer.end(ep) })
+	case err == nil:
+		c.balancer.done(ep, resp.StatusCode < 500, false, duration)
+	case ctx.Err() != nil:
+		c.balancer.done(ep, false, true, duration)
+	default:
+		c.balancer.done(ep, false, false, duration)
+	}
+
+	return resp, err
+}
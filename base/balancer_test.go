@@ -0,0 +1,425 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLoadBalancerConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config LoadBalancerConfig
+		errMsg string
+	}{
+		{"valid endpoints", LoadBalancerConfig{Endpoints: []string{"http://10.0.0.1:8080", "http://10.0.0.2:8080"}}, ""},
+		{"valid resolver", LoadBalancerConfig{Resolver: StaticResolver{"http://10.0.0.1:8080"}, Strategy: LoadBalancingPowerOfTwo}, ""},
+		{"missing endpoints", LoadBalancerConfig{}, "endpoints or resolver is required"},
+		{"endpoint without scheme", LoadBalancerConfig{Endpoints: []string{"10.0.0.1:8080"}}, "scheme and host are required"},
+		{"negative refresh interval", LoadBalancerConfig{Endpoints: []string{"http://a"}, RefreshInterval: -time.Second}, "refresh interval cannot be negative"},
+		{"unknown strategy", LoadBalancerConfig{Endpoints: []string{"http://a"}, Strategy: LoadBalancingStrategy(9)}, "unknown strategy"},
+		{"invalid circuit breaker", LoadBalancerConfig{Endpoints: []string{"http://a"}, CircuitBreaker: &CircuitBreakerConfig{}}, "circuit breaker config"},
+		{"invalid outlier detection", LoadBalancerConfig{Endpoints: []string{"http://a"}, OutlierDetection: &OutlierDetectionConfig{MaxEjectionPercent: 2}}, "max ejection percent must be between 0 and 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing '%s', got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestLoadBalancingStrategyString(t *testing.T) {
+	tests := []struct {
+		strategy LoadBalancingStrategy
+		expected string
+	}{
+		{LoadBalancingRoundRobin, "round_robin"},
+		{LoadBalancingLeastInFlight, "least_in_flight"},
+		{LoadBalancingPowerOfTwo, "power_of_two"},
+		{LoadBalancingStrategy(9), "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.strategy.String(); got != tt.expected {
+			t.Errorf("expected %s, got %s", tt.expected, got)
+		}
+	}
+}
+
+func TestEndpointURL(t *testing.T) {
+	base, _ := url.Parse("https://ride-service/api")
+	ep, _ := url.Parse("http://10.0.0.1:8080/v1")
+
+	tests := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{"rewrites base URL", "https://ride-service/api/rides/1?status=active", "http://10.0.0.1:8080/v1/rides/1?status=active"},
+		{"keeps escaped path segments", "https://ride-service/api/rides/a%2Fb/cancel", "http://10.0.0.1:8080/v1/rides/a%2Fb/cancel"},
+		{"keeps other hosts", "https://other-service/api/rides", "https://other-service/api/rides"},
+		{"keeps other paths", "https://ride-service/health", "https://ride-service/health"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, _ := url.Parse(tt.url)
+			if got := endpointURL(u, base, ep).String(); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestBalancer(t *testing.T) {
+	base, _ := url.Parse("http://service")
+	newTestBalancer := func(cfg *LoadBalancerConfig) *balancer {
+		return newBalancer(cfg, "service", base)
+	}
+	endpoints := []string{"http://a", "http://b", "http://c"}
+
+	t.Run("round robin", func(t *testing.T) {
+		b := newTestBalancer(&LoadBalancerConfig{Endpoints: endpoints})
+
+		var got []string
+		for i := 0; i < 4; i++ {
+			ep := b.pick(context.Background())
+			got = append(got, ep.url.Host)
+			b.done(ep, true, false, time.Millisecond)
+		}
+		if strings.Join(got, ",") != "a,b,c,a" {
+			t.Errorf("unexpected order: %v", got)
+		}
+	})
+
+	t.Run("least in flight", func(t *testing.T) {
+		b := newTestBalancer(&LoadBalancerConfig{Endpoints: endpoints, Strategy: LoadBalancingLeastInFlight})
+
+		first := b.pick(context.Background())
+		second := b.pick(context.Background())
+		third := b.pick(context.Background())
+		if first == second || second == third || first == third {
+			t.Fatal("expected every endpoint to get one request")
+		}
+
+		b.done(second, true, false, time.Millisecond)
+		if ep := b.pick(context.Background()); ep != second {
+			t.Errorf("expected the idle endpoint %s, got %s", second.raw, ep.raw)
+		}
+	})
+
+	t.Run("power of two prefers the less loaded endpoint", func(t *testing.T) {
+		b := newTestBalancer(&LoadBalancerConfig{Endpoints: []string{"http://a", "http://b"}, Strategy: LoadBalancingPowerOfTwo})
+
+		busy := b.pick(context.Background())
+		for i := 0; i < 10; i++ {
+			ep := b.pick(context.Background())
+			if ep == busy {
+				t.Fatal("expected the idle endpoint to be picked")
+			}
+			b.done(ep, true, false, time.Millisecond)
+		}
+	})
+
+	t.Run("ejects outliers", func(t *testing.T) {
+		b := newTestBalancer(&LoadBalancerConfig{
+			Endpoints:        endpoints,
+			OutlierDetection: &OutlierDetectionConfig{ConsecutiveFailures: 2, EjectionDuration: time.Minute},
+		})
+
+		bad := b.endpoints[0]
+		for i := 0; i < 2; i++ {
+			b.mu.Lock()
+			bad.inFlight++
+			b.mu.Unlock()
+			b.done(bad, false, false, time.Millisecond)
+		}
+
+		for i := 0; i < 6; i++ {
+			ep := b.pick(context.Background())
+			if ep == bad {
+				t.Fatal("expected the ejected endpoint to be skipped")
+			}
+			b.done(ep, true, false, time.Millisecond)
+		}
+
+		stats := b.stats()
+		if stats[0].EjectedUntil.IsZero() || stats[0].Failures != 2 {
+			t.Errorf("unexpected stats: %+v", stats[0])
+		}
+	})
+
+	t.Run("limits the ejected fraction", func(t *testing.T) {
+		b := newTestBalancer(&LoadBalancerConfig{
+			Endpoints:        []string{"http://a", "http://b"},
+			OutlierDetection: &OutlierDetectionConfig{ConsecutiveFailures: 1, MaxEjectionPercent: 0.5},
+		})
+
+		for _, ep := range b.endpoints {
+			b.mu.Lock()
+			ep.inFlight++
+			b.mu.Unlock()
+			b.done(ep, false, false, time.Millisecond)
+		}
+
+		if b.pick(context.Background()) == nil {
+			t.Error("expected one endpoint to stay available")
+		}
+	})
+
+	t.Run("skips endpoints with an open circuit", func(t *testing.T) {
+		b := newTestBalancer(&LoadBalancerConfig{
+			Endpoints:      []string{"http://a", "http://b"},
+			CircuitBreaker: &CircuitBreakerConfig{FailureThreshold: 1, SuccessThreshold: 1, Timeout: time.Minute},
+		})
+
+		ep := b.pick(context.Background())
+		b.done(ep, false, false, time.Millisecond)
+
+		for i := 0; i < 4; i++ {
+			next := b.pick(context.Background())
+			if next == ep {
+				t.Fatal("expected the open endpoint to be skipped")
+			}
+			b.done(next, true, false, time.Millisecond)
+		}
+
+		if state := b.stats()[0].CircuitState; state != CircuitOpen {
+			t.Errorf("expected open circuit, got %s", state)
+		}
+	})
+
+	t.Run("ignored attempts are not recorded", func(t *testing.T) {
+		b := newTestBalancer(&LoadBalancerConfig{
+			Endpoints:        []string{"http://a"},
+			OutlierDetection: &OutlierDetectionConfig{ConsecutiveFailures: 1},
+		})
+
+		ep := b.pick(context.Background())
+		b.done(ep, false, true, time.Millisecond)
+
+		stats := b.stats()[0]
+		if stats.InFlight != 0 || stats.Requests != 0 || !stats.EjectedUntil.IsZero() {
+			t.Errorf("unexpected stats: %+v", stats)
+		}
+	})
+
+	t.Run("ignored attempts release the half-open probe", func(t *testing.T) {
+		b := newTestBalancer(&LoadBalancerConfig{
+			Endpoints:      []string{"http://a"},
+			CircuitBreaker: &CircuitBreakerConfig{FailureThreshold: 1, SuccessThreshold: 1, Timeout: 10 * time.Millisecond},
+		})
+
+		ep := b.pick(context.Background())
+		b.done(ep, false, false, time.Millisecond)
+		time.Sleep(20 * time.Millisecond)
+
+		probe := b.pick(context.Background())
+		if probe == nil {
+			t.Fatal("expected a half-open probe")
+		}
+		b.done(probe, false, true, time.Millisecond)
+
+		if state := b.stats()[0].CircuitState; state != CircuitHalfOpen {
+			t.Fatalf("expected half-open circuit, got %s", state)
+		}
+		if b.pick(context.Background()) == nil {
+			t.Error("expected the endpoint to be picked again after an ignored probe")
+		}
+	})
+
+	t.Run("keeps endpoint state on refresh", func(t *testing.T) {
+		b := newTestBalancer(&LoadBalancerConfig{Resolver: StaticResolver{"http://a", "http://b"}})
+
+		ep := b.pick(context.Background())
+		b.done(ep, true, false, time.Millisecond)
+
+		b.setEndpoints([]string{"http://b", "http://a", "http://c"})
+		stats := b.stats()
+		if len(stats) != 3 {
+			t.Fatalf("expected 3 endpoints, got %d", len(stats))
+		}
+		for _, s := range stats {
+			if s.URL == ep.raw && s.Requests != 1 {
+				t.Errorf("expected the request count of %s to be kept, got %d", s.URL, s.Requests)
+			}
+		}
+	})
+}
+
+func TestClientLoadBalancer(t *testing.T) {
+	newServer := func(status int, hits *atomic.Int32) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits.Add(1)
+			w.WriteHeader(status)
+		}))
+	}
+
+	t.Run("spreads calls across endpoints", func(t *testing.T) {
+		var hitsA, hitsB atomic.Int32
+		serverA := newServer(http.StatusOK, &hitsA)
+		defer serverA.Close()
+		serverB := newServer(http.StatusOK, &hitsB)
+		defer serverB.Close()
+
+		client, err := NewClient(&Config{
+			BaseURL:      "http://ride-service",
+			LoadBalancer: &LoadBalancerConfig{Endpoints: []string{serverA.URL, serverB.URL}},
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		for i := 0; i < 4; i++ {
+			if _, err := client.Get(context.Background(), "/rides").Do(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		if hitsA.Load() != 2 || hitsB.Load() != 2 {
+			t.Errorf("expected 2 calls per endpoint, got %d and %d", hitsA.Load(), hitsB.Load())
+		}
+		if stats := client.EndpointStats(); len(stats) != 2 || stats[0].Requests != 2 {
+			t.Errorf("unexpected stats: %+v", stats)
+		}
+	})
+
+	t.Run("retries on another endpoint and ejects the bad one", func(t *testing.T) {
+		var hitsBad, hitsGood atomic.Int32
+		bad := newServer(http.StatusServiceUnavailable, &hitsBad)
+		defer bad.Close()
+		good := newServer(http.StatusOK, &hitsGood)
+		defer good.Close()
+
+		client, err := NewClient(&Config{
+			BaseURL: "http://ride-service",
+			Retry:   RetryConfig{MaxRetries: 1, InitialWait: time.Millisecond, MaxWait: time.Millisecond, Multiplier: 1.0},
+			LoadBalancer: &LoadBalancerConfig{
+				Resolver:         StaticResolver{bad.URL, good.URL},
+				OutlierDetection: &OutlierDetectionConfig{ConsecutiveFailures: 1, EjectionDuration: time.Minute},
+			},
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		for i := 0; i < 3; i++ {
+			resp, err := client.Get(context.Background(), "/rides").Do()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("expected status 200, got %d", resp.StatusCode)
+			}
+		}
+
+		if got := hitsBad.Load(); got != 1 {
+			t.Errorf("expected 1 call to the bad endpoint, got %d", got)
+		}
+	})
+
+	t.Run("fails without a healthy endpoint", func(t *testing.T) {
+		var hits atomic.Int32
+		server := newServer(http.StatusInternalServerError, &hits)
+		defer server.Close()
+
+		client, err := NewClient(&Config{
+			BaseURL: "http://ride-service",
+			Retry:   RetryConfig{MaxRetries: 0, Multiplier: 1.0},
+			LoadBalancer: &LoadBalancerConfig{
+				Endpoints:      []string{server.URL},
+				CircuitBreaker: &CircuitBreakerConfig{FailureThreshold: 1, SuccessThreshold: 1, Timeout: time.Minute},
+			},
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		_, _ = client.Get(context.Background(), "/rides").Do()
+		_, err = client.Get(context.Background(), "/rides").Do()
+		if !IsNoHealthyEndpoint(err) {
+			t.Errorf("expected no healthy endpoint error, got %v", err)
+		}
+		if got := hits.Load(); got != 1 {
+			t.Errorf("expected 1 call, got %d", got)
+		}
+	})
+
+	t.Run("keeps escaped path parameters", func(t *testing.T) {
+		paths := make(chan string, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			paths <- r.URL.EscapedPath()
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client, err := NewClient(&Config{
+			BaseURL:      "http://ride-service",
+			LoadBalancer: &LoadBalancerConfig{Endpoints: []string{server.URL}},
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		if _, err := client.Get(context.Background(), "/rides/{id}", Param("id", "a/b")).Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if path := <-paths; path != "/rides/a%2Fb" {
+			t.Errorf("expected escaped path /rides/a%%2Fb, got %s", path)
+		}
+	})
+
+	t.Run("keeps a streamed call in flight until the body is closed", func(t *testing.T) {
+		var hits atomic.Int32
+		server := newServer(http.StatusOK, &hits)
+		defer server.Close()
+
+		client, err := NewClient(&Config{
+			BaseURL:      "http://ride-service",
+			LoadBalancer: &LoadBalancerConfig{Endpoints: []string{server.URL}},
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		resp, err := client.Get(context.Background(), "/rides/export").Stream()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if stats := client.EndpointStats()[0]; stats.InFlight != 1 || stats.Requests != 1 {
+			t.Errorf("expected the open stream to be in flight and recorded, got %+v", stats)
+		}
+
+		_ = resp.Close()
+
+		if stats := client.EndpointStats()[0]; stats.InFlight != 0 {
+			t.Errorf("expected no request in flight after close, got %+v", stats)
+		}
+	})
+
+	t.Run("no stats without load balancer", func(t *testing.T) {
+		client, err := NewClient(&Config{BaseURL: "http://localhost:8080"}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		if client.EndpointStats() != nil {
+			t.Error("expected nil stats")
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package base
+
+import (
+	"sync"
+	"time"
+)
+
+// Number of buckets a retry budget window is divided into.
+const retryBudgetBuckets = 10
+
+// Retry budget defaults.
+const (
+	// Default maximum ratio of retries to requests.
+	defaultRetryBudgetRatio = 0.2
+
+	// Default number of retries per second allowed regardless of the ratio.
+	defaultRetryBudgetMinPerSecond = 10
+
+	// Default period over which requests and retries are counted.
+	defaultRetryBudgetWindow = 10 * time.Second
+)
+
+// RetryBudgetConfig holds retry budget configuration.
+type RetryBudgetConfig struct {
+	// Ratio is the maximum number of retries as a fraction of requests (default: 0.2).
+	Ratio float64
+
+	// MinRetriesPerSecond is the retry rate allowed regardless of the ratio,
+	// so that low-traffic clients can still retry (default: 10).
+	MinRetriesPerSecond int
+
+	// Window is the period over which requests and retries are counted (default: 10s).
+	Window time.Duration
+}
+
+// RetryBudget limits retries to a fraction of recent requests to prevent retry storms.
+// A single budget can be shared by several clients through RetryConfig.Budget.
+// It is safe for concurrent use.
+type RetryBudget struct {
+	ratio       float64
+	minRetries  float64
+	bucketWidth time.Duration
+
+	mu      sync.Mutex
+	buckets []budgetBucket
+}
+
+// budgetBucket holds the request and retry counts of one slice of the budget window.
+type budgetBucket struct {
+	epoch    int64
+	requests int
+	retries  int
+}
+
+// NewRetryBudget creates a new RetryBudget with the given configuration.
+func NewRetryBudget(cfg RetryBudgetConfig) *RetryBudget {
+	if cfg.Ratio <= 0 {
+		cfg.Ratio = defaultRetryBudgetRatio
+	}
+	if cfg.MinRetriesPerSecond <= 0 {
+		cfg.MinRetriesPerSecond = defaultRetryBudgetMinPerSecond
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = defaultRetryBudgetWindow
+	}
+
+	width := cfg.Window / retryBudgetBuckets
+	if width <= 0 {
+		width = 1
+	}
+
+	return &RetryBudget{
+		ratio:       cfg.Ratio,
+		minRetries:  float64(cfg.MinRetriesPerSecond) * cfg.Window.Seconds(),
+		bucketWidth: width,
+		buckets:     make([]budgetBucket, retryBudgetBuckets),
+	}
+}
+
+// RecordRequest records a new logical request, which adds to the retry budget.
+func (b *RetryBudget) RecordRequest() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.bucket(time.Now()).requests++
+}
+
+// AllowRetry reports whether a retry fits in the budget and, if so, records it.
+func (b *RetryBudget) AllowRetry() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	requests, retries := b.totals(now)
+	if float64(retries) >= b.minRetries+b.ratio*float64(requests) {
+		return false
+	}
+
+	b.bucket(now).retries++
+	return true
+}
+
+// bucket returns the current bucket, recycling it if it has expired.
+// It must be called with b.mu held.
+func (b *RetryBudget) bucket(now time.Time) *budgetBucket {
+	epoch := now.UnixNano() / int64(b.bucketWidth)
+	bucket := &b.buckets[epoch%int64(len(b.buckets))]
+	if bucket.epoch != epoch {
+		*bucket = budgetBucket{epoch: epoch}
+	}
+	return bucket
+}
+
+// totals returns the request and retry counts within the window.
+// It must be called with b.mu held.
+func (b *RetryBudget) totals(now time.Time) (requests, retries int) {
+	epoch := now.UnixNano() / int64(b.bucketWidth)
+	oldest := epoch - int64(len(b.buckets)) + 1
+
+	for _, bucket := range b.buckets {
+		if bucket.epoch < oldest || bucket.epoch > epoch {
+			continue
+		}
+		requests += bucket.requests
+		retries += bucket.retries
+	}
+	return requests, retries
+}
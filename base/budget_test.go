@@ -0,0 +1,138 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewRetryBudget(t *testing.T) {
+	b := NewRetryBudget(RetryBudgetConfig{})
+
+	if b.ratio != defaultRetryBudgetRatio {
+		t.Errorf("expected ratio %v, got %v", defaultRetryBudgetRatio, b.ratio)
+	}
+	if expected := float64(defaultRetryBudgetMinPerSecond) * defaultRetryBudgetWindow.Seconds(); b.minRetries != expected {
+		t.Errorf("expected %v minimum retries, got %v", expected, b.minRetries)
+	}
+	if b.bucketWidth != defaultRetryBudgetWindow/retryBudgetBuckets {
+		t.Errorf("unexpected bucket width %v", b.bucketWidth)
+	}
+}
+
+func TestRetryBudgetAllowRetry(t *testing.T) {
+	b := NewRetryBudget(RetryBudgetConfig{
+		Ratio:               0.5,
+		MinRetriesPerSecond: 1,
+		Window:              time.Second,
+	})
+
+	// The minimum allows one retry without any requests.
+	if !b.AllowRetry() {
+		t.Fatal("expected minimum retry to be allowed")
+	}
+	if b.AllowRetry() {
+		t.Fatal("expected retry beyond minimum to be denied")
+	}
+
+	// Four requests add two retries at a ratio of 0.5.
+	for i := 0; i < 4; i++ {
+		b.RecordRequest()
+	}
+	if !b.AllowRetry() || !b.AllowRetry() {
+		t.Fatal("expected retries within the ratio to be allowed")
+	}
+	if b.AllowRetry() {
+		t.Error("expected retry beyond the ratio to be denied")
+	}
+}
+
+func TestRetryBudgetExpires(t *testing.T) {
+	b := NewRetryBudget(RetryBudgetConfig{
+		Ratio:               0.1,
+		MinRetriesPerSecond: 10,
+		Window:              100 * time.Millisecond,
+	})
+
+	if !b.AllowRetry() {
+		t.Fatal("expected retry to be allowed")
+	}
+	if b.AllowRetry() {
+		t.Fatal("expected budget to be exhausted")
+	}
+
+	time.Sleep(120 * time.Millisecond)
+
+	if !b.AllowRetry() {
+		t.Error("expected budget to recover after the window")
+	}
+}
+
+func TestClientRetryBudget(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	metrics := &recordingMetrics{}
+	budget := NewRetryBudget(RetryBudgetConfig{
+		Ratio:               0.01,
+		MinRetriesPerSecond: 1,
+		Window:              10 * time.Second,
+	})
+
+	newClient := func() *Client {
+		client, err := NewClient(&Config{
+			BaseURL:        server.URL,
+			Timeout:        30 * time.Second,
+			RequestTimeout: 10 * time.Second,
+			Retry: RetryConfig{
+				MaxRetries:  3,
+				InitialWait: time.Millisecond,
+				MaxWait:     time.Millisecond,
+				Multiplier:  1.0,
+				Budget:      budget,
+			},
+			Metrics: metrics,
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		return client
+	}
+
+	// Two clients share a budget of about 10 retries, which the first client exhausts.
+	first, second := newClient(), newClient()
+	for i := 0; i < 10; i++ {
+		_, _ = first.Get(context.Background(), "/test").Do()
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	_, err := second.Get(context.Background(), "/test").Do()
+	if !IsRetryBudgetExhausted(err) {
+		t.Fatalf("expected retry budget exhausted error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected no retries once the budget is exhausted, got %d calls", calls)
+	}
+
+	// Per-request retry overrides still use the client budget.
+	atomic.StoreInt32(&calls, 0)
+	_, err = second.Get(context.Background(), "/test").
+		WithRetry(RetryConfig{MaxRetries: 2, InitialWait: time.Millisecond, MaxWait: time.Millisecond, Multiplier: 1.0}).
+		Do()
+	if !IsRetryBudgetExhausted(err) || calls != 1 {
+		t.Errorf("expected per-request retries to be budgeted, got %v after %d calls", err, calls)
+	}
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	if metrics.budgetExhausted == 0 {
+		t.Error("expected retry budget exhaustion to be recorded")
+	}
+}
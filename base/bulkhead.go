@@ -0,0 +1,298 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// BulkheadMode selects how the bulkhead limits concurrent calls.
+type BulkheadMode int
+
+const (
+	// BulkheadModeStatic allows at most MaxConcurrent calls in flight.
+	BulkheadModeStatic BulkheadMode = iota
+	// BulkheadModeAdaptive adjusts the limit with additive increase, multiplicative decrease
+	// (AIMD): the limit grows by one while calls succeed under load, and shrinks by
+	// BackoffRatio when a call fails, is rate limited or is slow.
+	BulkheadModeAdaptive
+)
+
+// String returns the string representation of the bulkhead mode.
+func (m BulkheadMode) String() string {
+	switch m {
+	case BulkheadModeStatic:
+		return "static"
+	case BulkheadModeAdaptive:
+		return "adaptive"
+	default:
+		return "unknown"
+	}
+}
+
+// Bulkhead defaults.
+const (
+	// Default maximum number of calls in flight in static mode.
+	defaultBulkheadMaxConcurrent = 100
+
+	// Default adaptive limits.
+	defaultBulkheadInitialLimit = 20
+	defaultBulkheadMinLimit     = 1
+	defaultBulkheadMaxLimit     = 200
+
+	// Default factor the adaptive limit is multiplied by on a failure.
+	defaultBulkheadBackoffRatio = 0.9
+)
+
+// BulkheadConfig holds concurrency limiter configuration.
+// The bulkhead rejects calls beyond the limit with a CodeBulkheadFull error
+// instead of letting a slow service exhaust goroutines and connections.
+type BulkheadConfig struct {
+	// Mode selects how the limit is set (default: BulkheadModeStatic).
+	Mode BulkheadMode
+
+	// MaxConcurrent is the maximum number of calls in flight in static mode (default: 100).
+	MaxConcurrent int
+
+	// InitialLimit is the starting limit in adaptive mode (default: 20).
+	InitialLimit int
+
+	// MinLimit is the lowest limit in adaptive mode (default: 1).
+	MinLimit int
+
+	// MaxLimit is the highest limit in adaptive mode (default: 200).
+	MaxLimit int
+
+	// BackoffRatio is the factor, between 0 and 1, the adaptive limit is multiplied by
+	// when a call fails or is slow (default: 0.9).
+	BackoffRatio float64
+
+	// SlowCallDuration is the latency above which a call shrinks the adaptive limit.
+	// Zero means only failures shrink it.
+	SlowCallDuration time.Duration
+}
+
+// Validate validates the bulkhead configuration.
+func (c *BulkheadConfig) Validate() error {
+	switch c.Mode {
+	case BulkheadModeStatic:
+		if c.MaxConcurrent < 0 {
+			return fmt.Errorf("max concurrent cannot be negative")
+		}
+	case BulkheadModeAdaptive:
+		return c.validateAdaptive()
+	default:
+		return fmt.Errorf("unknown mode %d", c.Mode)
+	}
+
+	return nil
+}
+
+// validateAdaptive validates the adaptive limit settings.
+func (c *BulkheadConfig) validateAdaptive() error {
+	if c.InitialLimit < 0 || c.MinLimit < 0 || c.MaxLimit < 0 {
+		return fmt.Errorf("limits cannot be negative")
+	}
+
+	if c.MaxLimit > 0 && c.MinLimit > c.MaxLimit {
+		return fmt.Errorf("min limit cannot exceed max limit")
+	}
+
+	if c.InitialLimit > 0 && (c.InitialLimit < c.MinLimit || (c.MaxLimit > 0 && c.InitialLimit > c.MaxLimit)) {
+		return fmt.Errorf("initial limit must be between min and max limit")
+	}
+
+	if c.BackoffRatio < 0 || c.BackoffRatio >= 1.0 {
+		return fmt.Errorf("backoff ratio must be between 0 and 1")
+	}
+
+	if c.SlowCallDuration < 0 {
+		return fmt.Errorf("slow call duration cannot be negative")
+	}
+
+	return nil
+}
+
+// BulkheadStats holds bulkhead statistics.
+type BulkheadStats struct {
+	Mode BulkheadMode
+
+	// Limit is the current maximum number of calls in flight.
+	Limit int
+	// InFlight is the number of calls in flight.
+	InFlight int
+	// Rejected is the number of calls rejected since the bulkhead was created.
+	Rejected int64
+}
+
+// Bulkhead limits the number of concurrent calls to a service.
+// It is safe for concurrent use.
+type Bulkhead struct {
+	mode             BulkheadMode
+	minLimit         int
+	maxLimit         int
+	backoffRatio     float64
+	slowCallDuration time.Duration
+
+	mu       sync.Mutex
+	limit    int
+	inFlight int
+	rejected int64
+}
+
+// NewBulkhead creates a new Bulkhead with the given configuration.
+func NewBulkhead(cfg *BulkheadConfig) *Bulkhead {
+	b := &Bulkhead{
+		mode:             cfg.Mode,
+		minLimit:         cfg.MinLimit,
+		maxLimit:         cfg.MaxLimit,
+		backoffRatio:     cfg.BackoffRatio,
+		slowCallDuration: cfg.SlowCallDuration,
+	}
+
+	if b.mode != BulkheadModeAdaptive {
+		b.limit = cfg.MaxConcurrent
+		if b.limit <= 0 {
+			b.limit = defaultBulkheadMaxConcurrent
+		}
+		return b
+	}
+
+	if b.minLimit <= 0 {
+		b.minLimit = defaultBulkheadMinLimit
+	}
+	if b.maxLimit <= 0 {
+		b.maxLimit = max(defaultBulkheadMaxLimit, b.minLimit)
+	}
+	if b.backoffRatio <= 0 {
+		b.backoffRatio = defaultBulkheadBackoffRatio
+	}
+
+	b.limit = cfg.InitialLimit
+	if b.limit <= 0 {
+		b.limit = defaultBulkheadInitialLimit
+	}
+	b.limit = min(max(b.limit, b.minLimit), b.maxLimit)
+
+	return b
+}
+
+// Acquire takes a slot for a call. It returns false if the limit is reached,
+// in which case the call must be rejected and Release must not be called.
+func (b *Bulkhead) Acquire() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.inFlight >= b.limit {
+		b.rejected++
+		return false
+	}
+
+	b.inFlight++
+	return true
+}
+
+// Release frees the slot of a completed call and, in adaptive mode, adjusts the
+// limit based on its outcome and latency.
+func (b *Bulkhead) Release(success bool, duration time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.mode == BulkheadModeAdaptive {
+		slow := b.slowCallDuration > 0 && duration > b.slowCallDuration
+		switch {
+		case !success || slow:
+			b.limit = max(int(float64(b.limit)*b.backoffRatio), b.minLimit)
+		case b.inFlight*2 >= b.limit:
+			// Only grow the limit when it is actually being used.
+			b.limit = min(b.limit+1, b.maxLimit)
+		}
+	}
+
+	b.inFlight--
+}
+
+// ReleaseIgnored frees the slot of a call whose outcome says nothing about the
+// service, such as a call cancelled by the caller, without adjusting the limit.
+func (b *Bulkhead) ReleaseIgnored() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.inFlight--
+}
+
+// Limit returns the current maximum number of calls in flight.
+func (b *Bulkhead) Limit() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.limit
+}
+
+// Stats returns the current statistics for the bulkhead.
+func (b *Bulkhead) Stats() BulkheadStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return BulkheadStats{
+		Mode:     b.mode,
+		Limit:    b.limit,
+		InFlight: b.inFlight,
+		Rejected: b.rejected,
+	}
+}
+
+// BulkheadMiddleware rejects calls beyond the client concurrency limit with a
+// CodeBulkheadFull error and reports the outcome of every other call to the bulkhead.
+// It has no effect if the client has no bulkhead configured.
+func BulkheadMiddleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *http.Request) (*Response, error) {
+			info := CallInfoFromContext(ctx)
+			if info == nil || info.client.bulkhead == nil {
+				return next(ctx, req)
+			}
+
+			bulkhead := info.client.bulkhead
+			metrics := info.client.metrics
+
+			if !bulkhead.Acquire() {
+				metrics.IncBulkheadRejection(info.Service)
+				return nil, ErrBulkheadFull(info.Service)
+			}
+
+			start := time.Now()
+			resp, err := next(ctx, req)
+
+			duration := info.attemptDuration
+			if duration == 0 {
+				duration = time.Since(start)
+			}
+
+			switch {
+			case err == nil:
+				success := resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests
+				if resp.stream != nil {
+					// A streamed call holds its slot until the body is closed. The time to the
+					// response headers is reported, since the body is read at the caller's pace.
+					resp.onClose(func() {
+						bulkhead.Release(success, duration)
+						metrics.SetBulkheadLimit(info.Service, bulkhead.Limit())
+					})
+					break
+				}
+				bulkhead.Release(success, duration)
+			case ctx.Err() != nil, IsCircuitOpen(err), IsRateLimitExceeded(err):
+				// Neither the caller giving up nor a local rejection says anything about the service load.
+				bulkhead.ReleaseIgnored()
+			default:
+				bulkhead.Release(false, duration)
+			}
+			metrics.SetBulkheadLimit(info.Service, bulkhead.Limit())
+
+			return resp, err
+		}
+	}
+}
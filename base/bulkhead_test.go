@@ -0,0 +1,327 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBulkheadConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config BulkheadConfig
+		errMsg string
+	}{
+		{"valid static", BulkheadConfig{MaxConcurrent: 10}, ""},
+		{"valid static defaults", BulkheadConfig{}, ""},
+		{"valid adaptive", BulkheadConfig{Mode: BulkheadModeAdaptive, InitialLimit: 10, MinLimit: 2, MaxLimit: 50, BackoffRatio: 0.8}, ""},
+		{"valid adaptive defaults", BulkheadConfig{Mode: BulkheadModeAdaptive}, ""},
+		{"negative max concurrent", BulkheadConfig{MaxConcurrent: -1}, "max concurrent cannot be negative"},
+		{"negative limit", BulkheadConfig{Mode: BulkheadModeAdaptive, MinLimit: -1}, "limits cannot be negative"},
+		{"min above max", BulkheadConfig{Mode: BulkheadModeAdaptive, MinLimit: 10, MaxLimit: 5}, "min limit cannot exceed max limit"},
+		{"initial below min", BulkheadConfig{Mode: BulkheadModeAdaptive, InitialLimit: 1, MinLimit: 5}, "initial limit must be between min and max limit"},
+		{"initial above max", BulkheadConfig{Mode: BulkheadModeAdaptive, InitialLimit: 100, MaxLimit: 50}, "initial limit must be between min and max limit"},
+		{"backoff ratio of 1", BulkheadConfig{Mode: BulkheadModeAdaptive, BackoffRatio: 1.0}, "backoff ratio must be between 0 and 1"},
+		{"negative slow call duration", BulkheadConfig{Mode: BulkheadModeAdaptive, SlowCallDuration: -time.Second}, "slow call duration cannot be negative"},
+		{"unknown mode", BulkheadConfig{Mode: BulkheadMode(9)}, "unknown mode"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing '%s', got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestBulkheadModeString(t *testing.T) {
+	tests := []struct {
+		mode     BulkheadMode
+		expected string
+	}{
+		{BulkheadModeStatic, "static"},
+		{BulkheadModeAdaptive, "adaptive"},
+		{BulkheadMode(9), "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.mode.String(); got != tt.expected {
+			t.Errorf("expected %s, got %s", tt.expected, got)
+		}
+	}
+}
+
+func TestBulkheadStatic(t *testing.T) {
+	t.Run("rejects calls beyond the limit", func(t *testing.T) {
+		b := NewBulkhead(&BulkheadConfig{MaxConcurrent: 2})
+
+		if !b.Acquire() || !b.Acquire() {
+			t.Fatal("expected first two calls to be allowed")
+		}
+		if b.Acquire() {
+			t.Fatal("expected third call to be rejected")
+		}
+
+		b.Release(false, time.Second)
+		if !b.Acquire() {
+			t.Error("expected call to be allowed after a release")
+		}
+
+		stats := b.Stats()
+		if stats.Limit != 2 || stats.InFlight != 2 || stats.Rejected != 1 {
+			t.Errorf("unexpected stats: %+v", stats)
+		}
+	})
+
+	t.Run("uses default limit", func(t *testing.T) {
+		b := NewBulkhead(&BulkheadConfig{})
+		if b.Limit() != defaultBulkheadMaxConcurrent {
+			t.Errorf("expected limit %d, got %d", defaultBulkheadMaxConcurrent, b.Limit())
+		}
+	})
+}
+
+func TestBulkheadAdaptive(t *testing.T) {
+	newBulkhead := func() *Bulkhead {
+		return NewBulkhead(&BulkheadConfig{
+			Mode:             BulkheadModeAdaptive,
+			InitialLimit:     4,
+			MinLimit:         2,
+			MaxLimit:         5,
+			BackoffRatio:     0.5,
+			SlowCallDuration: 100 * time.Millisecond,
+		})
+	}
+
+	t.Run("grows on success under load", func(t *testing.T) {
+		b := newBulkhead()
+		b.Acquire()
+		b.Acquire()
+
+		b.Release(true, time.Millisecond)
+		if b.Limit() != 5 {
+			t.Errorf("expected limit 5, got %d", b.Limit())
+		}
+
+		b.Acquire()
+		b.Acquire()
+		b.Release(true, time.Millisecond)
+		if b.Limit() != 5 {
+			t.Errorf("expected limit capped at 5, got %d", b.Limit())
+		}
+	})
+
+	t.Run("does not grow when underused", func(t *testing.T) {
+		b := newBulkhead()
+		b.Acquire()
+
+		b.Release(true, time.Millisecond)
+		if b.Limit() != 4 {
+			t.Errorf("expected limit 4, got %d", b.Limit())
+		}
+	})
+
+	t.Run("shrinks on failure", func(t *testing.T) {
+		b := newBulkhead()
+		b.Acquire()
+
+		b.Release(false, time.Millisecond)
+		if b.Limit() != 2 {
+			t.Errorf("expected limit 2, got %d", b.Limit())
+		}
+
+		b.Acquire()
+		b.Release(false, time.Millisecond)
+		if b.Limit() != 2 {
+			t.Errorf("expected limit floored at 2, got %d", b.Limit())
+		}
+	})
+
+	t.Run("shrinks on slow call", func(t *testing.T) {
+		b := newBulkhead()
+		b.Acquire()
+
+		b.Release(true, 200*time.Millisecond)
+		if b.Limit() != 2 {
+			t.Errorf("expected limit 2, got %d", b.Limit())
+		}
+	})
+
+	t.Run("ignored release keeps the limit", func(t *testing.T) {
+		b := newBulkhead()
+		b.Acquire()
+
+		b.ReleaseIgnored()
+		stats := b.Stats()
+		if stats.Limit != 4 || stats.InFlight != 0 {
+			t.Errorf("unexpected stats: %+v", stats)
+		}
+	})
+
+	t.Run("uses defaults", func(t *testing.T) {
+		b := NewBulkhead(&BulkheadConfig{Mode: BulkheadModeAdaptive})
+		if b.Limit() != defaultBulkheadInitialLimit {
+			t.Errorf("expected limit %d, got %d", defaultBulkheadInitialLimit, b.Limit())
+		}
+		if b.Stats().Mode != BulkheadModeAdaptive {
+			t.Errorf("expected adaptive mode, got %s", b.Stats().Mode)
+		}
+	})
+}
+
+func TestClientBulkhead(t *testing.T) {
+	newClient := func(t *testing.T, serverURL string, cfg *BulkheadConfig, metrics Metrics) *Client {
+		t.Helper()
+		client, err := NewClient(&Config{
+			BaseURL:        serverURL,
+			Timeout:        5 * time.Second,
+			RequestTimeout: 5 * time.Second,
+			Retry:          RetryConfig{MaxRetries: 0, Multiplier: 1.0},
+			Bulkhead:       cfg,
+			Metrics:        metrics,
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		return client
+	}
+
+	t.Run("rejects calls beyond the limit", func(t *testing.T) {
+		started := make(chan struct{})
+		unblock := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			started <- struct{}{}
+			<-unblock
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		metrics := &recordingMetrics{}
+		client := newClient(t, server.URL, &BulkheadConfig{MaxConcurrent: 1}, metrics)
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := client.Get(context.Background(), "/incidents").Do()
+			done <- err
+		}()
+		<-started
+
+		_, err := client.Get(context.Background(), "/incidents").Do()
+		if !IsBulkheadFull(err) {
+			t.Errorf("expected bulkhead full error, got %v", err)
+		}
+
+		close(unblock)
+		if err := <-done; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		stats := client.BulkheadStats()
+		if stats == nil {
+			t.Fatal("expected bulkhead stats")
+		}
+		if stats.Limit != 1 || stats.InFlight != 0 || stats.Rejected != 1 {
+			t.Errorf("unexpected stats: %+v", stats)
+		}
+
+		metrics.mu.Lock()
+		defer metrics.mu.Unlock()
+		if metrics.bulkheadRejects != 1 {
+			t.Errorf("expected 1 rejection, got %d", metrics.bulkheadRejects)
+		}
+	})
+
+	t.Run("adaptive limit shrinks on server errors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		metrics := &recordingMetrics{}
+		client := newClient(t, server.URL, &BulkheadConfig{
+			Mode:         BulkheadModeAdaptive,
+			InitialLimit: 10,
+			BackoffRatio: 0.5,
+		}, metrics)
+
+		_, _ = client.Get(context.Background(), "/test").Do()
+
+		if limit := client.BulkheadStats().Limit; limit != 5 {
+			t.Errorf("expected limit 5, got %d", limit)
+		}
+
+		metrics.mu.Lock()
+		defer metrics.mu.Unlock()
+		if len(metrics.bulkheadLimits) != 1 || metrics.bulkheadLimits[0] != 5 {
+			t.Errorf("expected recorded limit 5, got %v", metrics.bulkheadLimits)
+		}
+	})
+
+	t.Run("ignores calls cancelled by the caller", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		client := newClient(t, server.URL, &BulkheadConfig{Mode: BulkheadModeAdaptive, InitialLimit: 10}, nil)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, _ = client.Get(ctx, "/test").Do()
+
+		stats := client.BulkheadStats()
+		if stats.Limit != 10 || stats.InFlight != 0 {
+			t.Errorf("unexpected stats: %+v", stats)
+		}
+	})
+
+	t.Run("holds the slot of a streamed call until the body is closed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			_, _ = w.Write([]byte("{}\n"))
+		}))
+		defer server.Close()
+
+		client := newClient(t, server.URL, &BulkheadConfig{MaxConcurrent: 1}, nil)
+
+		resp, err := client.Get(context.Background(), "/rides/export").Stream()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if stats := client.BulkheadStats(); stats.InFlight != 1 {
+			t.Errorf("expected the open stream to be in flight, got %+v", stats)
+		}
+		if _, err := client.Get(context.Background(), "/rides").Do(); !IsBulkheadFull(err) {
+			t.Errorf("expected bulkhead full error while the stream is open, got %v", err)
+		}
+
+		_ = resp.Close()
+		_ = resp.Close()
+
+		if stats := client.BulkheadStats(); stats.InFlight != 0 {
+			t.Errorf("expected the slot to be released once, got %+v", stats)
+		}
+		if _, err := client.Get(context.Background(), "/rides").Do(); err != nil {
+			t.Errorf("unexpected error after the stream is closed: %v", err)
+		}
+	})
+
+	t.Run("no stats without bulkhead", func(t *testing.T) {
+		client := newClient(t, "http://localhost:8080", nil, nil)
+		if client.BulkheadStats() != nil {
+			t.Error("expected nil stats")
+		}
+	})
+}
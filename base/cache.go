@@ -0,0 +1,570 @@
+package base
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache defaults.
+const (
+	// Default number of entries in the LRU cache.
+	defaultCacheMaxEntries = 1000
+
+	// Default largest response body stored in the cache.
+	defaultCacheMaxEntrySize = 1 << 20
+)
+
+// Cache lookup results reported to Metrics.
+const (
+	cacheResultHit         = "hit"
+	cacheResultMiss        = "miss"
+	cacheResultRevalidated = "revalidated"
+	cacheResultStale       = "stale"
+	cacheResultBypass      = "bypass"
+)
+
+// CacheEntry is a cached response.
+type CacheEntry struct {
+	StatusCode int
+	Headers    http.Header
+	Body       []byte
+
+	// StoredAt is when the response was received or last revalidated.
+	StoredAt time.Time
+
+	// Expires is when the entry becomes stale. Stale entries are only used after the
+	// service confirms them with a 304 Not Modified response, or within the
+	// stale-while-revalidate and stale-if-error windows.
+	Expires time.Time
+
+	// Vary holds the request header values named by the Vary response header.
+	// The entry is only used for requests with the same values.
+	Vary map[string]string
+}
+
+// Cache stores cached responses by key. Implementations must be safe for concurrent use.
+// The client treats errors as cache misses, so a cache outage only costs latency.
+type Cache interface {
+	// Get returns the entry for key, or nil if there is none.
+	Get(ctx context.Context, key string) (*CacheEntry, error)
+
+	// Set stores the entry for key, replacing any existing entry.
+	Set(ctx context.Context, key string, entry *CacheEntry) error
+
+	// Delete removes the entry for key, if any.
+	Delete(ctx context.Context, key string) error
+}
+
+// CacheConfig holds response cache configuration.
+// Only GET responses with status 200 are cached. The cache follows HTTP semantics:
+// Cache-Control max-age, no-cache and no-store, Expires and Age set how long a response
+// is fresh, and stale responses with an ETag or Last-Modified header are revalidated
+// with If-None-Match and If-Modified-Since.
+type CacheConfig struct {
+	// Cache stores the responses (default: an LRUCache with MaxEntries entries).
+	// Use a shared implementation, such as one backed by Redis, to share the cache between clients.
+	Cache Cache
+
+	// MaxEntries is the size of the default LRU cache (default: 1000). Ignored if Cache is set.
+	MaxEntries int
+
+	// DefaultTTL is how long responses without Cache-Control max-age or Expires headers are
+	// fresh. If zero, such responses are only cached if they have a validator, and are
+	// revalidated on every call.
+	DefaultTTL time.Duration
+
+	// MaxEntrySize is the largest response body cached, in bytes (default: 1 MiB).
+	MaxEntrySize int
+
+	// StaleWhileRevalidate is how long after a response becomes stale it is still returned,
+	// marked stale, while it is refreshed in the background. A stale-while-revalidate
+	// Cache-Control directive on the response takes precedence. Zero disables it.
+	StaleWhileRevalidate time.Duration
+
+	// StaleIfError is how long after a response becomes stale it is still returned, marked
+	// stale, when a call fails: the circuit is open, retries are exhausted or the service
+	// returns a 5xx status. A stale-if-error Cache-Control directive on the response takes
+	// precedence. Zero disables it.
+	StaleIfError time.Duration
+}
+
+// Validate validates the cache configuration.
+func (c *CacheConfig) Validate() error {
+	if c.MaxEntries < 0 {
+		return fmt.Errorf("max entries cannot be negative")
+	}
+
+	if c.DefaultTTL < 0 {
+		return fmt.Errorf("default TTL cannot be negative")
+	}
+
+	if c.MaxEntrySize < 0 {
+		return fmt.Errorf("max entry size cannot be negative")
+	}
+
+	if c.StaleWhileRevalidate < 0 {
+		return fmt.Errorf("stale while revalidate cannot be negative")
+	}
+
+	if c.StaleIfError < 0 {
+		return fmt.Errorf("stale if error cannot be negative")
+	}
+
+	return nil
+}
+
+// LRUCache is an in-memory Cache that evicts the least recently used entry when full.
+// It is safe for concurrent use.
+type LRUCache struct {
+	maxEntries int
+
+	mu    sync.Mutex
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+// lruItem is an LRUCache list element value.
+type lruItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewLRUCache creates an LRUCache holding up to maxEntries entries (default: 1000).
+func NewLRUCache(maxEntries int) *LRUCache {
+	if maxEntries <= 0 {
+		maxEntries = defaultCacheMaxEntries
+	}
+
+	return &LRUCache{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+// Get implements Cache.
+func (c *LRUCache) Get(_ context.Context, key string) (*CacheEntry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, nil
+	}
+	c.ll.MoveToFront(el)
+	return el.Value.(*lruItem).entry, nil
+}
+
+// Set implements Cache.
+func (c *LRUCache) Set(_ context.Context, key string, entry *CacheEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*lruItem).entry = entry
+		c.ll.MoveToFront(el)
+		return nil
+	}
+
+	c.items[key] = c.ll.PushFront(&lruItem{key: key, entry: entry})
+	if c.ll.Len() > c.maxEntries {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruItem).key)
+	}
+	return nil
+}
+
+// Delete implements Cache.
+func (c *LRUCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.ll.Remove(el)
+		delete(c.items, key)
+	}
+	return nil
+}
+
+// Len returns the number of entries in the cache.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ll.Len()
+}
+
+// responseCache applies HTTP caching semantics on top of a Cache.
+type responseCache struct {
+	cache                Cache
+	defaultTTL           time.Duration
+	maxEntrySize         int
+	staleWhileRevalidate time.Duration
+	staleIfError         time.Duration
+
+	mu         sync.Mutex
+	refreshing map[string]bool
+}
+
+// newResponseCache creates the response cache for a client, or returns nil if cfg is nil.
+func newResponseCache(cfg *CacheConfig) *responseCache {
+	if cfg == nil {
+		return nil
+	}
+
+	cache := cfg.Cache
+	if cache == nil {
+		cache = NewLRUCache(cfg.MaxEntries)
+	}
+
+	maxEntrySize := cfg.MaxEntrySize
+	if maxEntrySize == 0 {
+		maxEntrySize = defaultCacheMaxEntrySize
+	}
+
+	return &responseCache{
+		cache:                cache,
+		defaultTTL:           cfg.DefaultTTL,
+		maxEntrySize:         maxEntrySize,
+		staleWhileRevalidate: cfg.StaleWhileRevalidate,
+		staleIfError:         cfg.StaleIfError,
+		refreshing:           make(map[string]bool),
+	}
+}
+
+// cacheKey returns the cache key of a GET request to u.
+func cacheKey(u string) string {
+	return http.MethodGet + " " + u
+}
+
+// lookup returns the entry matching the request, or nil.
+func (rc *responseCache) lookup(ctx context.Context, key string, req *http.Request) *CacheEntry {
+	entry, err := rc.cache.Get(ctx, key)
+	if err != nil || entry == nil {
+		return nil
+	}
+
+	for name, value := range entry.Vary {
+		if req.Header.Get(name) != value {
+			return nil
+		}
+	}
+	return entry
+}
+
+// store caches the response to req if it is cacheable.
+func (rc *responseCache) store(ctx context.Context, key string, req *http.Request, resp *Response, now time.Time) {
+	if resp.StatusCode != http.StatusOK || len(resp.Body) > rc.maxEntrySize {
+		return
+	}
+
+	cc := parseCacheControl(resp.Headers)
+	if _, ok := cc["no-store"]; ok {
+		return
+	}
+
+	entry := &CacheEntry{
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Headers.Clone(),
+		Body:       slices.Clone(resp.Body),
+		StoredAt:   now,
+		Expires:    rc.freshUntil(resp.Headers, now),
+	}
+	if !entry.Expires.After(now) && !entry.hasValidator() {
+		return
+	}
+
+	for _, field := range resp.Headers.Values("Vary") {
+		for _, name := range strings.Split(field, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if name == "*" {
+				return
+			}
+			if entry.Vary == nil {
+				entry.Vary = make(map[string]string)
+			}
+			entry.Vary[http.CanonicalHeaderKey(name)] = req.Header.Get(name)
+		}
+	}
+
+	_ = rc.cache.Set(ctx, key, entry)
+}
+
+// revalidated returns a copy of entry updated with the headers of a 304 Not Modified response.
+func (rc *responseCache) revalidated(entry *CacheEntry, headers http.Header, now time.Time) *CacheEntry {
+	updated := *entry
+	updated.Headers = entry.Headers.Clone()
+	maps.Copy(updated.Headers, headers)
+	updated.StoredAt = now
+	updated.Expires = rc.freshUntil(updated.Headers, now)
+	return &updated
+}
+
+// freshUntil returns when a response with the given headers, received at now, becomes stale.
+func (rc *responseCache) freshUntil(headers http.Header, now time.Time) time.Time {
+	cc := parseCacheControl(headers)
+	if _, ok := cc["no-cache"]; ok {
+		return now
+	}
+
+	var age time.Duration
+	if seconds, err := strconv.Atoi(headers.Get("Age")); err == nil && seconds > 0 {
+		age = time.Duration(seconds) * time.Second
+	}
+
+	if value, ok := cc["max-age"]; ok {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds <= 0 {
+			return now
+		}
+		return now.Add(time.Duration(seconds)*time.Second - age)
+	}
+
+	if value := headers.Get("Expires"); value != "" {
+		expires, err := http.ParseTime(value)
+		if err != nil {
+			return now
+		}
+		lifetime := time.Until(expires)
+		if date, err := http.ParseTime(headers.Get("Date")); err == nil {
+			lifetime = expires.Sub(date)
+		}
+		return now.Add(lifetime - age)
+	}
+
+	return now.Add(rc.defaultTTL)
+}
+
+// staleWindow returns how long after the entry becomes stale it can still be returned,
+// using the Cache-Control directive if the response has one and the default otherwise.
+// Responses with must-revalidate or no-cache are never returned stale.
+func (e *CacheEntry) staleWindow(directive string, defaultWindow time.Duration) time.Duration {
+	cc := parseCacheControl(e.Headers)
+	if _, ok := cc["must-revalidate"]; ok {
+		return 0
+	}
+	if _, ok := cc["no-cache"]; ok {
+		return 0
+	}
+
+	if value, ok := cc[directive]; ok {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return defaultWindow
+}
+
+// usableUntil reports whether the entry can be returned stale at now within its window.
+func (e *CacheEntry) usableUntil(now time.Time, window time.Duration) bool {
+	return window > 0 && now.Before(e.Expires.Add(window))
+}
+
+// hasValidator reports whether the entry can be revalidated.
+func (e *CacheEntry) hasValidator() bool {
+	return e.Headers.Get("ETag") != "" || e.Headers.Get("Last-Modified") != ""
+}
+
+// response returns a copy of the cached response.
+func (e *CacheEntry) response(stale bool) *Response {
+	return &Response{
+		StatusCode: e.StatusCode,
+		Headers:    e.Headers.Clone(),
+		Body:       slices.Clone(e.Body),
+		Stale:      stale,
+	}
+}
+
+// conditionalRequest returns a copy of req that asks the service to confirm the entry.
+func (e *CacheEntry) conditionalRequest(ctx context.Context, req *http.Request) *http.Request {
+	condReq := req.Clone(ctx)
+	if etag := e.Headers.Get("ETag"); etag != "" {
+		condReq.Header.Set("If-None-Match", etag)
+	}
+	if lastModified := e.Headers.Get("Last-Modified"); lastModified != "" {
+		condReq.Header.Set("If-Modified-Since", lastModified)
+	}
+	return condReq
+}
+
+// parseCacheControl parses the Cache-Control directives of a header set.
+// Directive names are lower-cased; directives without a value map to "".
+func parseCacheControl(headers http.Header) map[string]string {
+	directives := make(map[string]string)
+	for _, field := range headers.Values("Cache-Control") {
+		for _, directive := range strings.Split(field, ",") {
+			name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			if name == "" {
+				continue
+			}
+			directives[strings.ToLower(name)] = strings.Trim(value, `"`)
+		}
+	}
+	return directives
+}
+
+// isCacheableRequest reports whether the response to req may be served from or stored in the cache.
+// Requests with their own conditional or range headers are passed through untouched.
+func isCacheableRequest(req *http.Request) bool {
+	if req.Method != http.MethodGet {
+		return false
+	}
+
+	for _, header := range []string{"If-None-Match", "If-Modified-Since", "Range"} {
+		if req.Header.Get(header) != "" {
+			return false
+		}
+	}
+
+	_, noStore := parseCacheControl(req.Header)["no-store"]
+	return !noStore
+}
+
+// fetch sends the call, revalidating entry if it has a validator, and updates the cache.
+// It reports whether the service confirmed the entry with a 304 Not Modified response.
+func (rc *responseCache) fetch(ctx context.Context, key string, req *http.Request, entry *CacheEntry, next Handler) (*Response, bool, error) {
+	sendReq := req
+	if entry != nil && entry.hasValidator() {
+		sendReq = entry.conditionalRequest(ctx, req)
+	}
+
+	resp, err := next(ctx, sendReq)
+	if err != nil {
+		return nil, false, err
+	}
+
+	now := time.Now()
+	if resp.StatusCode == http.StatusNotModified && sendReq != req {
+		updated := rc.revalidated(entry, resp.Headers, now)
+		_ = rc.cache.Set(ctx, key, updated)
+		return updated.response(false), true, nil
+	}
+
+	rc.store(ctx, key, req, resp, now)
+	return resp, false, nil
+}
+
+// refreshInBackground refreshes a stale entry unless a refresh for key is already running.
+// The refresh is not cancelled with the call and is bounded by the client call timeout.
+func (rc *responseCache) refreshInBackground(ctx context.Context, key string, req *http.Request, entry *CacheEntry, next Handler) {
+	rc.mu.Lock()
+	if rc.refreshing[key] {
+		rc.mu.Unlock()
+		return
+	}
+	rc.refreshing[key] = true
+	rc.mu.Unlock()
+
+	// The refresh gets its own CallInfo since the call's is still in use by its middleware.
+	info := *CallInfoFromContext(ctx)
+	info.attempts = 0
+	info.attemptDuration = 0
+	refreshCtx := withCallInfo(context.WithoutCancel(ctx), &info)
+
+	cancel := context.CancelFunc(func() {})
+	if timeout := info.client.timeout; timeout > 0 {
+		refreshCtx, cancel = context.WithTimeout(refreshCtx, timeout)
+	}
+	refreshReq := req.Clone(refreshCtx)
+
+	go func() {
+		defer cancel()
+		defer func() {
+			rc.mu.Lock()
+			delete(rc.refreshing, key)
+			rc.mu.Unlock()
+		}()
+
+		_, _, _ = rc.fetch(refreshCtx, key, refreshReq, entry, next)
+	}()
+}
+
+// CacheMiddleware serves GET calls from the client response cache and stores cacheable
+// responses. Stale responses are returned, with Response.Stale set, while they are refreshed
+// in the background or when the call fails, within the configured windows. Successful calls
+// with an unsafe method, such as POST or DELETE, invalidate the cached response for their URL.
+// It has no effect if the client has no cache configured or the call is streamed.
+func CacheMiddleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *http.Request) (*Response, error) {
+			info := CallInfoFromContext(ctx)
+			if info == nil || info.client.cache == nil || info.stream {
+				return next(ctx, req)
+			}
+
+			rc := info.client.cache
+			metrics := info.client.metrics
+			key := cacheKey(req.URL.String())
+
+			if !isCacheableRequest(req) {
+				resp, err := next(ctx, req)
+				if err == nil && resp.StatusCode < 400 && !isSafeMethod(req.Method) {
+					_ = rc.cache.Delete(ctx, key)
+				}
+				return resp, err
+			}
+
+			// A request with Cache-Control: no-cache skips the lookup like a bypassed call.
+			_, noCache := parseCacheControl(req.Header)["no-cache"]
+			bypass := info.bypassCache || noCache
+
+			var entry *CacheEntry
+			if bypass {
+				metrics.IncCacheLookup(info.labels(req.Method), cacheResultBypass)
+			} else {
+				entry = rc.lookup(ctx, key, req)
+			}
+
+			if entry != nil {
+				now := time.Now()
+				if now.Before(entry.Expires) {
+					metrics.IncCacheLookup(info.labels(req.Method), cacheResultHit)
+					return entry.response(false), nil
+				}
+				if entry.usableUntil(now, entry.staleWindow("stale-while-revalidate", rc.staleWhileRevalidate)) {
+					rc.refreshInBackground(ctx, key, req, entry, next)
+					metrics.IncCacheLookup(info.labels(req.Method), cacheResultStale)
+					return entry.response(true), nil
+				}
+			}
+
+			resp, revalidated, err := rc.fetch(ctx, key, req, entry, next)
+			if (err != nil || resp.StatusCode >= 500) && entry != nil &&
+				entry.usableUntil(time.Now(), entry.staleWindow("stale-if-error", rc.staleIfError)) {
+				metrics.IncCacheLookup(info.labels(req.Method), cacheResultStale)
+				return entry.response(true), nil
+			}
+
+			switch {
+			case bypass:
+			case revalidated:
+				metrics.IncCacheLookup(info.labels(req.Method), cacheResultRevalidated)
+			default:
+				metrics.IncCacheLookup(info.labels(req.Method), cacheResultMiss)
+			}
+			return resp, err
+		}
+	}
+}
+
+// isSafeMethod reports whether the method is read-only.
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
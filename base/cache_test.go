@@ -0,0 +1,414 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config CacheConfig
+		errMsg string
+	}{
+		{"valid", CacheConfig{MaxEntries: 100, DefaultTTL: time.Minute}, ""},
+		{"negative max entries", CacheConfig{MaxEntries: -1}, "max entries cannot be negative"},
+		{"negative default TTL", CacheConfig{DefaultTTL: -time.Second}, "default TTL cannot be negative"},
+		{"negative max entry size", CacheConfig{MaxEntrySize: -1}, "max entry size cannot be negative"},
+		{"negative stale while revalidate", CacheConfig{StaleWhileRevalidate: -time.Second}, "stale while revalidate cannot be negative"},
+		{"negative stale if error", CacheConfig{StaleIfError: -time.Second}, "stale if error cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing '%s', got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRUCache(2)
+
+	_ = cache.Set(ctx, "a", &CacheEntry{Body: []byte("a")})
+	_ = cache.Set(ctx, "b", &CacheEntry{Body: []byte("b")})
+	if entry, _ := cache.Get(ctx, "a"); entry == nil {
+		t.Fatal("expected entry a")
+	}
+
+	// b is now the least recently used entry.
+	_ = cache.Set(ctx, "c", &CacheEntry{Body: []byte("c")})
+	if entry, _ := cache.Get(ctx, "b"); entry != nil {
+		t.Error("expected entry b to be evicted")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", cache.Len())
+	}
+
+	_ = cache.Delete(ctx, "a")
+	if entry, _ := cache.Get(ctx, "a"); entry != nil {
+		t.Error("expected entry a to be deleted")
+	}
+}
+
+func TestResponseCacheFreshUntil(t *testing.T) {
+	now := time.Now()
+	rc := newResponseCache(&CacheConfig{DefaultTTL: time.Minute})
+
+	tests := []struct {
+		name     string
+		headers  http.Header
+		expected time.Duration
+	}{
+		{"max-age", http.Header{"Cache-Control": {"public, max-age=300"}}, 5 * time.Minute},
+		{"max-age minus age", http.Header{"Cache-Control": {"max-age=300"}, "Age": {"100"}}, 200 * time.Second},
+		{"no-cache", http.Header{"Cache-Control": {"no-cache, max-age=300"}}, 0},
+		{"invalid max-age", http.Header{"Cache-Control": {"max-age=soon"}}, 0},
+		{"expires", http.Header{
+			"Date":    {now.UTC().Format(http.TimeFormat)},
+			"Expires": {now.Add(10 * time.Minute).UTC().Format(http.TimeFormat)},
+		}, 10 * time.Minute},
+		{"invalid expires", http.Header{"Expires": {"0"}}, 0},
+		{"default TTL", http.Header{}, time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rc.freshUntil(tt.headers, now).Sub(now)
+			if diff := got - tt.expected; diff < -time.Second || diff > time.Second {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestClientCache(t *testing.T) {
+	newClient := func(t *testing.T, baseURL string, metrics Metrics) *Client {
+		t.Helper()
+		client, err := NewClient(&Config{
+			BaseURL: baseURL,
+			Cache:   &CacheConfig{},
+			Metrics: metrics,
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		return client
+	}
+
+	t.Run("serves fresh responses from the cache", func(t *testing.T) {
+		var hits atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits.Add(1)
+			w.Header().Set("Cache-Control", "max-age=60")
+			_, _ = w.Write([]byte(`{"id":"economy"}`))
+		}))
+		defer server.Close()
+
+		metrics := &recordingMetrics{}
+		client := newClient(t, server.URL, metrics)
+
+		for i := 0; i < 3; i++ {
+			resp, err := client.Get(context.Background(), "/service-types").Do()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(resp.Body) != `{"id":"economy"}` {
+				t.Errorf("unexpected body: %s", resp.Body)
+			}
+		}
+
+		if got := hits.Load(); got != 1 {
+			t.Errorf("expected 1 call to the service, got %d", got)
+		}
+		if got := strings.Join(metrics.cacheLookups, ","); got != "miss,hit,hit" {
+			t.Errorf("unexpected cache lookups: %s", got)
+		}
+	})
+
+	t.Run("revalidates stale responses with validators", func(t *testing.T) {
+		var hits, notModified atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits.Add(1)
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Cache-Control", "no-cache")
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				notModified.Add(1)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			_, _ = w.Write([]byte(`{"rating":4.8}`))
+		}))
+		defer server.Close()
+
+		client := newClient(t, server.URL, nil)
+
+		for i := 0; i < 2; i++ {
+			resp, err := client.Get(context.Background(), "/drivers/d1/rating").Do()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.StatusCode != http.StatusOK || string(resp.Body) != `{"rating":4.8}` {
+				t.Errorf("unexpected response: %d %s", resp.StatusCode, resp.Body)
+			}
+		}
+
+		if hits.Load() != 2 || notModified.Load() != 1 {
+			t.Errorf("expected 2 calls with 1 revalidation, got %d and %d", hits.Load(), notModified.Load())
+		}
+	})
+
+	t.Run("does not store no-store responses", func(t *testing.T) {
+		var hits atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits.Add(1)
+			w.Header().Set("Cache-Control", "no-store, max-age=60")
+			w.Header().Set("ETag", `"v1"`)
+		}))
+		defer server.Close()
+
+		client := newClient(t, server.URL, nil)
+		for i := 0; i < 2; i++ {
+			if _, err := client.Get(context.Background(), "/users/u1").Do(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		if got := hits.Load(); got != 2 {
+			t.Errorf("expected 2 calls to the service, got %d", got)
+		}
+	})
+
+	t.Run("bypass fetches a fresh response", func(t *testing.T) {
+		var hits atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits.Add(1)
+			w.Header().Set("Cache-Control", "max-age=60")
+		}))
+		defer server.Close()
+
+		client := newClient(t, server.URL, nil)
+		if _, err := client.Get(context.Background(), "/users/u1").Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := client.Get(context.Background(), "/users/u1").WithCacheBypass().Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := hits.Load(); got != 2 {
+			t.Errorf("expected 2 calls to the service, got %d", got)
+		}
+	})
+
+	t.Run("unsafe methods invalidate the cached response", func(t *testing.T) {
+		var hits atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				hits.Add(1)
+			}
+			w.Header().Set("Cache-Control", "max-age=60")
+		}))
+		defer server.Close()
+
+		client := newClient(t, server.URL, nil)
+		ctx := context.Background()
+		if _, err := client.Get(ctx, "/users/u1").Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := client.Patch(ctx, "/users/u1", map[string]string{"name": "Ana"}).Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := client.Get(ctx, "/users/u1").Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := hits.Load(); got != 2 {
+			t.Errorf("expected 2 GET calls to the service, got %d", got)
+		}
+	})
+
+	t.Run("matches Vary request headers", func(t *testing.T) {
+		var hits atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits.Add(1)
+			w.Header().Set("Cache-Control", "max-age=60")
+			w.Header().Set("Vary", "Accept-Language")
+		}))
+		defer server.Close()
+
+		client := newClient(t, server.URL, nil)
+		ctx := context.Background()
+		for _, lang := range []string{"pt", "pt", "en"} {
+			if _, err := client.Get(ctx, "/service-types").WithHeader("Accept-Language", lang).Do(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		if got := hits.Load(); got != 2 {
+			t.Errorf("expected 2 calls to the service, got %d", got)
+		}
+	})
+}
+
+func TestCacheEntryStaleWindow(t *testing.T) {
+	tests := []struct {
+		name     string
+		headers  http.Header
+		expected time.Duration
+	}{
+		{"default", http.Header{"Cache-Control": {"max-age=60"}}, time.Minute},
+		{"directive", http.Header{"Cache-Control": {"max-age=60, stale-if-error=300"}}, 5 * time.Minute},
+		{"must-revalidate", http.Header{"Cache-Control": {"max-age=60, must-revalidate, stale-if-error=300"}}, 0},
+		{"no-cache", http.Header{"Cache-Control": {"no-cache"}}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := &CacheEntry{Headers: tt.headers}
+			if got := entry.staleWindow("stale-if-error", time.Minute); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestClientCacheStale(t *testing.T) {
+	t.Run("serves stale responses when the circuit is open", func(t *testing.T) {
+		var status atomic.Int32
+		status.Store(http.StatusOK)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Cache-Control", "max-age=1")
+			w.WriteHeader(int(status.Load()))
+			_, _ = w.Write([]byte(`{"surge":1.2}`))
+		}))
+		defer server.Close()
+
+		client, err := NewClient(&Config{
+			BaseURL:        server.URL,
+			Retry:          RetryConfig{MaxRetries: 1, InitialWait: time.Millisecond, MaxWait: time.Millisecond, Multiplier: 1.0},
+			CircuitBreaker: &CircuitBreakerConfig{FailureThreshold: 1, SuccessThreshold: 1, Timeout: time.Minute},
+			Cache:          &CacheConfig{StaleIfError: time.Hour},
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		ctx := context.Background()
+		if _, err := client.Get(ctx, "/surge").Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// Expire the cached response and break the service.
+		entry, _ := client.cache.cache.Get(ctx, cacheKey(server.URL+"/surge"))
+		entry.Expires = time.Now().Add(-time.Second)
+		status.Store(http.StatusServiceUnavailable)
+
+		for i := 0; i < 2; i++ {
+			resp, err := client.Get(ctx, "/surge").Do()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.Stale || resp.StatusCode != http.StatusOK || string(resp.Body) != `{"surge":1.2}` {
+				t.Errorf("expected the stale response, got %d %s (stale %v)", resp.StatusCode, resp.Body, resp.Stale)
+			}
+		}
+		if stats := client.CircuitBreakerStats(); stats.State != CircuitOpen {
+			t.Errorf("expected the circuit to be open, got %s", stats.State)
+		}
+	})
+
+	t.Run("returns the error outside the stale window", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Cache-Control", "max-age=60")
+		}))
+		client, err := NewClient(&Config{
+			BaseURL: server.URL,
+			Retry:   RetryConfig{MaxRetries: 1, InitialWait: time.Millisecond, MaxWait: time.Millisecond, Multiplier: 1.0},
+			Cache:   &CacheConfig{StaleIfError: time.Minute},
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		ctx := context.Background()
+		if _, err := client.Get(ctx, "/surge").Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		entry, _ := client.cache.cache.Get(ctx, cacheKey(server.URL+"/surge"))
+		entry.Expires = time.Now().Add(-2 * time.Minute)
+		server.Close()
+
+		if _, err := client.Get(ctx, "/surge").Do(); err == nil {
+			t.Error("expected an error")
+		}
+	})
+
+	t.Run("serves stale responses while revalidating", func(t *testing.T) {
+		var hits atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := hits.Add(1)
+			w.Header().Set("Cache-Control", "max-age=60")
+			_, _ = fmt.Fprintf(w, `{"version":%d}`, n)
+		}))
+		defer server.Close()
+
+		client, err := NewClient(&Config{
+			BaseURL: server.URL,
+			Cache:   &CacheConfig{StaleWhileRevalidate: time.Minute},
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		ctx := context.Background()
+		if _, err := client.Get(ctx, "/service-types").Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		key := cacheKey(server.URL + "/service-types")
+		entry, _ := client.cache.cache.Get(ctx, key)
+		entry.Expires = time.Now().Add(-time.Second)
+
+		resp, err := client.Get(ctx, "/service-types").Do()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !resp.Stale || string(resp.Body) != `{"version":1}` {
+			t.Errorf("expected the stale response, got %s (stale %v)", resp.Body, resp.Stale)
+		}
+
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			entry, _ := client.cache.cache.Get(ctx, key)
+			if string(entry.Body) == `{"version":2}` {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("expected the entry to be refreshed in the background")
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		resp, err = client.Get(ctx, "/service-types").Do()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Stale || string(resp.Body) != `{"version":2}` {
+			t.Errorf("expected the fresh response, got %s (stale %v)", resp.Body, resp.Stale)
+		}
+	})
+}
@@ -17,8 +17,51 @@ const (
 	CircuitHalfOpen
 )
 
-// Default max concurrent probes in half-open state.
-const defaultMaxConcurrentProbes = 1
+// CircuitBreakerMode selects how the circuit breaker decides to open.
+type CircuitBreakerMode int
+
+const (
+	// CircuitModeConsecutive opens the circuit after FailureThreshold consecutive failures.
+	CircuitModeConsecutive CircuitBreakerMode = iota
+	// CircuitModeCountWindow opens the circuit based on the failure and slow-call rates
+	// of the last WindowSize calls.
+	CircuitModeCountWindow
+	// CircuitModeTimeWindow opens the circuit based on the failure and slow-call rates
+	// of the calls made within the last WindowDuration.
+	CircuitModeTimeWindow
+)
+
+// String returns the string representation of the circuit breaker mode.
+func (m CircuitBreakerMode) String() string {
+	switch m {
+	case CircuitModeConsecutive:
+		return "consecutive"
+	case CircuitModeCountWindow:
+		return "count-window"
+	case CircuitModeTimeWindow:
+		return "time-window"
+	default:
+		return "unknown"
+	}
+}
+
+// Circuit breaker defaults.
+const (
+	// Default max concurrent probes in half-open state.
+	defaultMaxConcurrentProbes = 1
+
+	// Default number of calls in a count window.
+	defaultWindowSize = 100
+
+	// Default duration of a time window.
+	defaultWindowDuration = 60 * time.Second
+
+	// Default minimum number of calls in a window before the rates are evaluated.
+	defaultMinimumRequests = 10
+
+	// Default failure rate that opens the circuit in window modes.
+	defaultFailureRateThreshold = 0.5
+)
 
 // String returns the string representation of the circuit state.
 func (s CircuitState) String() string {
@@ -34,6 +77,24 @@ func (s CircuitState) String() string {
 	}
 }
 
+// CircuitStateChange describes a circuit breaker state transition.
+type CircuitStateChange struct {
+	// Name is the name of the circuit breaker.
+	Name string
+
+	// From is the state before the transition.
+	From CircuitState
+
+	// To is the state after the transition.
+	To CircuitState
+
+	// Stats is a snapshot of the circuit breaker statistics after the transition.
+	Stats CircuitBreakerStats
+
+	// Time is when the transition happened.
+	Time time.Time
+}
+
 // CircuitBreaker implements the circuit breaker pattern.
 // It prevents cascading failures by stopping requests to unhealthy services.
 type CircuitBreaker struct {
@@ -44,8 +105,14 @@ type CircuitBreaker struct {
 	consecutiveFailures  int
 	consecutiveSuccesses int
 	lastFailureTime      time.Time
+	slowCalls            int
+	lastSlowCallTime     time.Time
 	inFlightProbes       int
 	maxConcurrentProbes  int
+	window               slidingWindow
+
+	subMu       sync.Mutex
+	subscribers map[chan CircuitStateChange]struct{}
 }
 
 // NewCircuitBreaker creates a new CircuitBreaker with the given configuration.
@@ -55,10 +122,21 @@ func NewCircuitBreaker(config *CircuitBreakerConfig) *CircuitBreaker {
 		maxProbes = defaultMaxConcurrentProbes
 	}
 
+	cfg := *config
+	if cfg.Mode != CircuitModeConsecutive {
+		if cfg.MinimumRequests <= 0 {
+			cfg.MinimumRequests = defaultMinimumRequests
+		}
+		if cfg.FailureRateThreshold <= 0 {
+			cfg.FailureRateThreshold = defaultFailureRateThreshold
+		}
+	}
+
 	return &CircuitBreaker{
-		config:              *config,
+		config:              cfg,
 		state:               CircuitClosed,
 		maxConcurrentProbes: maxProbes,
+		window:              newSlidingWindow(&cfg),
 	}
 }
 
@@ -67,48 +145,96 @@ func NewCircuitBreaker(config *CircuitBreakerConfig) *CircuitBreaker {
 // In half-open state, only a limited number of concurrent probe requests are allowed.
 func (cb *CircuitBreaker) Allow() bool {
 	cb.mu.Lock()
-	defer cb.mu.Unlock()
+	allowed, change := cb.allowLocked()
+	cb.mu.Unlock()
+
+	cb.notify(change)
+	return allowed
+}
 
+// allowLocked implements Allow. It must be called with cb.mu held.
+func (cb *CircuitBreaker) allowLocked() (bool, *CircuitStateChange) {
 	switch cb.state {
 	case CircuitClosed:
-		return true
+		return true, nil
 
 	case CircuitOpen:
 		// Check if timeout has elapsed.
 		if time.Since(cb.lastFailureTime) >= cb.config.Timeout {
 			// Transition to half-open.
-			cb.state = CircuitHalfOpen
 			cb.consecutiveSuccesses = 0
 			cb.inFlightProbes = 0
 			// Allow first probe request.
 			cb.inFlightProbes++
-			return true
+			return true, cb.transitionLocked(CircuitHalfOpen)
 		}
-		return false
+		return false, nil
 
 	case CircuitHalfOpen:
 		// Only allow probe requests if under the limit.
 		if cb.inFlightProbes < cb.maxConcurrentProbes {
 			cb.inFlightProbes++
-			return true
+			return true, nil
 		}
 		// Reject excess probes while half-open.
-		return false
+		return false, nil
 
 	default:
-		return true
+		return true, nil
+	}
+}
+
+// Release gives back the probe slot taken by an allowed request that ends without a
+// result, such as a request cancelled by the caller. It records nothing and has no effect
+// unless the circuit is half-open.
+func (cb *CircuitBreaker) Release() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == CircuitHalfOpen && cb.inFlightProbes > 0 {
+		cb.inFlightProbes--
 	}
 }
 
 // RecordSuccess records a successful request.
 func (cb *CircuitBreaker) RecordSuccess() {
+	cb.RecordResult(true, 0)
+}
+
+// RecordResult records the outcome and latency of a request.
+// Calls at or above SlowCallDuration are slow. They count towards the slow-call rate
+// if SlowCallRateThreshold is set in a window mode, and as failures otherwise.
+// A slow probe in half-open state always counts as a failure.
+func (cb *CircuitBreaker) RecordResult(success bool, duration time.Duration) {
+	slow := cb.config.SlowCallDuration > 0 && duration >= cb.config.SlowCallDuration
+
 	cb.mu.Lock()
-	defer cb.mu.Unlock()
+	if slow {
+		cb.slowCalls++
+		cb.lastSlowCallTime = time.Now()
+		if success && (cb.state == CircuitHalfOpen || !cb.slowCallRateEnabled()) {
+			success = false
+		}
+	}
+
+	var change *CircuitStateChange
+	if success {
+		change = cb.recordSuccessLocked(slow)
+	} else {
+		change = cb.recordFailureLocked(slow)
+	}
+	cb.mu.Unlock()
+
+	cb.notify(change)
+}
 
+// recordSuccessLocked implements RecordSuccess. It must be called with cb.mu held.
+func (cb *CircuitBreaker) recordSuccessLocked(slow bool) *CircuitStateChange {
 	switch cb.state {
 	case CircuitClosed:
 		// Reset failure count on success.
 		cb.consecutiveFailures = 0
+		return cb.recordWindowLocked(false, slow)
 
 	case CircuitHalfOpen:
 		// Decrement in-flight probes.
@@ -118,33 +244,41 @@ func (cb *CircuitBreaker) RecordSuccess() {
 		cb.consecutiveSuccesses++
 		// If enough successes, close the circuit.
 		if cb.consecutiveSuccesses >= cb.config.SuccessThreshold {
-			cb.state = CircuitClosed
 			cb.consecutiveFailures = 0
 			cb.consecutiveSuccesses = 0
 			cb.inFlightProbes = 0
+			cb.resetWindowLocked()
+			return cb.transitionLocked(CircuitClosed)
 		}
 
 	case CircuitOpen:
 		// Should not happen, but handle gracefully.
-		cb.state = CircuitHalfOpen
 		cb.consecutiveSuccesses = 1
 		cb.inFlightProbes = 0
+		return cb.transitionLocked(CircuitHalfOpen)
 	}
+
+	return nil
 }
 
 // RecordFailure records a failed request.
 func (cb *CircuitBreaker) RecordFailure() {
-	cb.mu.Lock()
-	defer cb.mu.Unlock()
+	cb.RecordResult(false, 0)
+}
 
+// recordFailureLocked implements RecordFailure. It must be called with cb.mu held.
+func (cb *CircuitBreaker) recordFailureLocked(slow bool) *CircuitStateChange {
 	cb.lastFailureTime = time.Now()
 
 	switch cb.state {
 	case CircuitClosed:
 		cb.consecutiveFailures++
+		if cb.window != nil {
+			return cb.recordWindowLocked(true, slow)
+		}
 		// If too many failures, open the circuit.
 		if cb.consecutiveFailures >= cb.config.FailureThreshold {
-			cb.state = CircuitOpen
+			return cb.transitionLocked(CircuitOpen)
 		}
 
 	case CircuitHalfOpen:
@@ -153,13 +287,55 @@ func (cb *CircuitBreaker) RecordFailure() {
 			cb.inFlightProbes--
 		}
 		// Failure in half-open state reopens the circuit.
-		cb.state = CircuitOpen
 		cb.consecutiveSuccesses = 0
 		cb.inFlightProbes = 0
+		return cb.transitionLocked(CircuitOpen)
 
 	case CircuitOpen:
 		// Already open, just update timestamp.
 	}
+
+	return nil
+}
+
+// recordWindowLocked records a closed-state outcome in the sliding window and opens
+// the circuit if a rate threshold is exceeded. It must be called with cb.mu held.
+func (cb *CircuitBreaker) recordWindowLocked(failure, slow bool) *CircuitStateChange {
+	if cb.window == nil {
+		return nil
+	}
+
+	now := time.Now()
+	cb.window.record(failure, slow, now)
+
+	counts := cb.window.counts(now)
+	if counts.requests < cb.config.MinimumRequests {
+		return nil
+	}
+
+	tripped := counts.failureRate() >= cb.config.FailureRateThreshold ||
+		(cb.config.SlowCallRateThreshold > 0 && counts.slowCallRate() >= cb.config.SlowCallRateThreshold)
+	if !tripped {
+		return nil
+	}
+
+	// The open timeout starts now, even if the call that tripped the circuit succeeded slowly.
+	cb.lastFailureTime = now
+	change := cb.transitionLocked(CircuitOpen)
+	cb.resetWindowLocked()
+	return change
+}
+
+// slowCallRateEnabled reports whether slow calls are tracked as a rate rather than as failures.
+func (cb *CircuitBreaker) slowCallRateEnabled() bool {
+	return cb.window != nil && cb.config.SlowCallRateThreshold > 0
+}
+
+// resetWindowLocked clears the sliding window. It must be called with cb.mu held.
+func (cb *CircuitBreaker) resetWindowLocked() {
+	if cb.window != nil {
+		cb.window.reset()
+	}
 }
 
 // State returns the current state of the circuit breaker.
@@ -177,13 +353,17 @@ func (cb *CircuitBreaker) Name() string {
 // Reset resets the circuit breaker to its initial state.
 func (cb *CircuitBreaker) Reset() {
 	cb.mu.Lock()
-	defer cb.mu.Unlock()
-
-	cb.state = CircuitClosed
 	cb.consecutiveFailures = 0
 	cb.consecutiveSuccesses = 0
 	cb.lastFailureTime = time.Time{}
+	cb.slowCalls = 0
+	cb.lastSlowCallTime = time.Time{}
 	cb.inFlightProbes = 0
+	cb.resetWindowLocked()
+	change := cb.transitionLocked(CircuitClosed)
+	cb.mu.Unlock()
+
+	cb.notify(change)
 }
 
 // Stats returns statistics about the circuit breaker.
@@ -194,6 +374,22 @@ type CircuitBreakerStats struct {
 	LastFailureTime      time.Time
 	InFlightProbes       int
 	MaxConcurrentProbes  int
+
+	// SlowCalls is the number of calls classified as slow since the last reset.
+	SlowCalls int
+	// LastSlowCallTime is when the last slow call was recorded.
+	LastSlowCallTime time.Time
+
+	// WindowRequests is the number of calls in the sliding window (window modes only).
+	WindowRequests int
+	// FailureRate is the fraction of failed calls in the sliding window (window modes only).
+	FailureRate float64
+	// SlowCallRate is the fraction of slow calls in the sliding window (window modes only).
+	SlowCallRate float64
+
+	// ActiveEndpoint is the base URL calls are sent to. It is only set by
+	// Client.CircuitBreakerStats for clients with failover configured.
+	ActiveEndpoint string
 }
 
 // Stats returns the current statistics for the circuit breaker.
@@ -201,12 +397,103 @@ func (cb *CircuitBreaker) Stats() CircuitBreakerStats {
 	cb.mu.RLock()
 	defer cb.mu.RUnlock()
 
-	return CircuitBreakerStats{
+	return cb.statsLocked()
+}
+
+// statsLocked returns the statistics. It must be called with cb.mu held.
+func (cb *CircuitBreaker) statsLocked() CircuitBreakerStats {
+	stats := CircuitBreakerStats{
 		State:                cb.state,
 		ConsecutiveFailures:  cb.consecutiveFailures,
 		ConsecutiveSuccesses: cb.consecutiveSuccesses,
 		LastFailureTime:      cb.lastFailureTime,
 		InFlightProbes:       cb.inFlightProbes,
 		MaxConcurrentProbes:  cb.maxConcurrentProbes,
+		SlowCalls:            cb.slowCalls,
+		LastSlowCallTime:     cb.lastSlowCallTime,
+	}
+
+	if cb.window != nil {
+		counts := cb.window.counts(time.Now())
+		stats.WindowRequests = counts.requests
+		stats.FailureRate = counts.failureRate()
+		stats.SlowCallRate = counts.slowCallRate()
+	}
+
+	return stats
+}
+
+// transitionLocked moves the circuit to the given state and returns the change,
+// or nil if the state is unchanged. It must be called with cb.mu held.
+func (cb *CircuitBreaker) transitionLocked(to CircuitState) *CircuitStateChange {
+	from := cb.state
+	if from == to {
+		return nil
+	}
+
+	cb.state = to
+
+	return &CircuitStateChange{
+		Name:  cb.config.Name,
+		From:  from,
+		To:    to,
+		Stats: cb.statsLocked(),
+		Time:  time.Now(),
+	}
+}
+
+// notify delivers a state change to the OnStateChange hook and all subscribers.
+// It must be called without cb.mu held so that hooks can inspect the breaker.
+func (cb *CircuitBreaker) notify(change *CircuitStateChange) {
+	if change == nil {
+		return
+	}
+
+	if cb.config.OnStateChange != nil {
+		cb.config.OnStateChange(change.From, change.To, change.Stats)
+	}
+
+	cb.subMu.Lock()
+	defer cb.subMu.Unlock()
+
+	for ch := range cb.subscribers {
+		// Never block the request path on a slow subscriber.
+		select {
+		case ch <- *change:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a channel that receives every state change, and a function
+// that cancels the subscription and closes the channel.
+// Changes are dropped if the channel buffer is full.
+func (cb *CircuitBreaker) Subscribe(buffer int) (<-chan CircuitStateChange, func()) {
+	ch := make(chan CircuitStateChange, buffer)
+	return ch, subscribeAll(ch, cb)
+}
+
+// subscribeAll sends the state changes of every breaker to ch. It returns a function
+// that cancels the subscriptions and closes ch.
+func subscribeAll(ch chan CircuitStateChange, breakers ...*CircuitBreaker) func() {
+	for _, cb := range breakers {
+		cb.subMu.Lock()
+		if cb.subscribers == nil {
+			cb.subscribers = make(map[chan CircuitStateChange]struct{})
+		}
+		cb.subscribers[ch] = struct{}{}
+		cb.subMu.Unlock()
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			for _, cb := range breakers {
+				cb.subMu.Lock()
+				delete(cb.subscribers, ch)
+				cb.subMu.Unlock()
+			}
+			close(ch)
+		})
 	}
 }
@@ -1,6 +1,10 @@
 package base
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -281,6 +285,39 @@ func TestCircuitBreakerAllowInHalfOpen(t *testing.T) {
 	}
 }
 
+func TestCircuitBreakerRelease(t *testing.T) {
+	cb := NewCircuitBreaker(&CircuitBreakerConfig{
+		FailureThreshold: 1,
+		SuccessThreshold: 2,
+		Timeout:          10 * time.Millisecond,
+	})
+
+	// Release has no effect while closed.
+	cb.Release()
+	if stats := cb.Stats(); stats.InFlightProbes != 0 {
+		t.Errorf("expected 0 in-flight probes, got %d", stats.InFlightProbes)
+	}
+
+	cb.RecordFailure()
+	time.Sleep(20 * time.Millisecond)
+
+	if !cb.Allow() {
+		t.Fatal("expected probe to be allowed")
+	}
+	if cb.Allow() {
+		t.Fatal("expected probe limit to be reached")
+	}
+
+	cb.Release()
+
+	if cb.State() != CircuitHalfOpen {
+		t.Errorf("expected state to stay half-open, got %s", cb.State())
+	}
+	if !cb.Allow() {
+		t.Error("expected Allow() to return true after Release()")
+	}
+}
+
 func TestCircuitBreakerMaxConcurrentProbes(t *testing.T) {
 	// Configure multiple concurrent probes.
 	cb := NewCircuitBreaker(&CircuitBreakerConfig{
@@ -377,3 +414,373 @@ func TestCircuitBreakerRecordSuccessInOpenState(t *testing.T) {
 		t.Errorf("expected state HalfOpen after success in Open, got %s", cb.State())
 	}
 }
+
+func TestCircuitBreakerModeString(t *testing.T) {
+	tests := []struct {
+		mode     CircuitBreakerMode
+		expected string
+	}{
+		{CircuitModeConsecutive, "consecutive"},
+		{CircuitModeCountWindow, "count-window"},
+		{CircuitModeTimeWindow, "time-window"},
+		{CircuitBreakerMode(99), "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.mode.String(); got != tt.expected {
+			t.Errorf("expected %s, got %s", tt.expected, got)
+		}
+	}
+}
+
+func TestCircuitBreakerCountWindowFailureRate(t *testing.T) {
+	cb := NewCircuitBreaker(&CircuitBreakerConfig{
+		Mode:                 CircuitModeCountWindow,
+		SuccessThreshold:     1,
+		Timeout:              30 * time.Second,
+		WindowSize:           10,
+		MinimumRequests:      5,
+		FailureRateThreshold: 0.4,
+	})
+
+	// Intermittent failures never trip the consecutive threshold.
+	cb.RecordFailure()
+	cb.RecordSuccess()
+	cb.RecordFailure()
+	cb.RecordSuccess()
+
+	if cb.State() != CircuitClosed {
+		t.Fatalf("expected state Closed below minimum requests, got %s", cb.State())
+	}
+
+	cb.RecordSuccess()
+
+	stats := cb.Stats()
+	if stats.State != CircuitOpen {
+		t.Fatalf("expected state Open at 40%% failure rate, got %s", stats.State)
+	}
+	if stats.WindowRequests != 0 {
+		t.Errorf("expected window to be cleared after opening, got %d requests", stats.WindowRequests)
+	}
+}
+
+func TestCircuitBreakerCountWindowStaysClosed(t *testing.T) {
+	cb := NewCircuitBreaker(&CircuitBreakerConfig{
+		Mode:             CircuitModeCountWindow,
+		SuccessThreshold: 1,
+		Timeout:          30 * time.Second,
+		WindowSize:       10,
+		MinimumRequests:  5,
+	})
+
+	for i := 0; i < 20; i++ {
+		if i%4 == 0 {
+			cb.RecordFailure()
+		} else {
+			cb.RecordSuccess()
+		}
+	}
+
+	stats := cb.Stats()
+	if stats.State != CircuitClosed {
+		t.Fatalf("expected state Closed at 25%% failure rate, got %s", stats.State)
+	}
+	if stats.WindowRequests != 10 {
+		t.Errorf("expected 10 window requests, got %d", stats.WindowRequests)
+	}
+	if stats.FailureRate < 0.2 || stats.FailureRate > 0.3 {
+		t.Errorf("expected failure rate between 0.2 and 0.3, got %v", stats.FailureRate)
+	}
+}
+
+func TestCircuitBreakerSlowCallRate(t *testing.T) {
+	cb := NewCircuitBreaker(&CircuitBreakerConfig{
+		Mode:                  CircuitModeTimeWindow,
+		SuccessThreshold:      1,
+		Timeout:               30 * time.Second,
+		WindowDuration:        time.Minute,
+		MinimumRequests:       4,
+		SlowCallRateThreshold: 0.5,
+		SlowCallDuration:      time.Second,
+	})
+
+	cb.RecordResult(true, 2*time.Second)
+	cb.RecordResult(true, 10*time.Millisecond)
+	cb.RecordResult(true, 3*time.Second)
+
+	stats := cb.Stats()
+	if stats.SlowCallRate < 0.6 || stats.FailureRate != 0 {
+		t.Errorf("unexpected rates: slow=%v failure=%v", stats.SlowCallRate, stats.FailureRate)
+	}
+
+	cb.RecordResult(true, 10*time.Millisecond)
+
+	if cb.State() != CircuitOpen {
+		t.Errorf("expected state Open at 50%% slow call rate, got %s", cb.State())
+	}
+	if cb.Allow() {
+		t.Error("expected Allow to return false after slow-call trip")
+	}
+}
+
+func TestCircuitBreakerSlowCallsCountAsFailures(t *testing.T) {
+	cb := NewCircuitBreaker(&CircuitBreakerConfig{
+		FailureThreshold: 2,
+		SuccessThreshold: 1,
+		Timeout:          10 * time.Millisecond,
+		SlowCallDuration: time.Second,
+	})
+
+	cb.RecordResult(true, 2*time.Second)
+	cb.RecordResult(true, 10*time.Millisecond)
+	cb.RecordResult(true, 2*time.Second)
+
+	stats := cb.Stats()
+	if stats.State != CircuitClosed {
+		t.Fatalf("expected state Closed after non-consecutive slow calls, got %s", stats.State)
+	}
+	if stats.SlowCalls != 2 || stats.LastSlowCallTime.IsZero() {
+		t.Errorf("expected 2 slow calls with a timestamp, got %+v", stats)
+	}
+
+	cb.RecordResult(true, 2*time.Second)
+	if cb.State() != CircuitOpen {
+		t.Fatalf("expected state Open after consecutive slow calls, got %s", cb.State())
+	}
+
+	// A slow probe reopens the circuit.
+	time.Sleep(15 * time.Millisecond)
+	if !cb.Allow() {
+		t.Fatal("expected probe to be allowed")
+	}
+	cb.RecordResult(true, 2*time.Second)
+	if cb.State() != CircuitOpen {
+		t.Errorf("expected state Open after slow probe, got %s", cb.State())
+	}
+
+	cb.Reset()
+	if stats := cb.Stats(); stats.SlowCalls != 0 || !stats.LastSlowCallTime.IsZero() {
+		t.Errorf("expected slow calls to be cleared after reset, got %+v", stats)
+	}
+}
+
+func TestCircuitBreakerSlowCallsIgnoredWithoutDuration(t *testing.T) {
+	cb := NewCircuitBreaker(&CircuitBreakerConfig{
+		FailureThreshold: 1,
+		SuccessThreshold: 1,
+		Timeout:          30 * time.Second,
+	})
+
+	cb.RecordResult(true, time.Hour)
+
+	if stats := cb.Stats(); stats.State != CircuitClosed || stats.SlowCalls != 0 {
+		t.Errorf("expected slow-call detection to be disabled, got %+v", stats)
+	}
+}
+
+func TestCircuitBreakerWindowResetsOnClose(t *testing.T) {
+	cb := NewCircuitBreaker(&CircuitBreakerConfig{
+		Mode:             CircuitModeCountWindow,
+		SuccessThreshold: 1,
+		Timeout:          10 * time.Millisecond,
+		WindowSize:       4,
+		MinimumRequests:  2,
+	})
+
+	cb.RecordFailure()
+	cb.RecordFailure()
+	if cb.State() != CircuitOpen {
+		t.Fatalf("expected state Open, got %s", cb.State())
+	}
+
+	time.Sleep(15 * time.Millisecond)
+	if !cb.Allow() {
+		t.Fatal("expected probe to be allowed")
+	}
+	cb.RecordSuccess()
+
+	if cb.State() != CircuitClosed {
+		t.Fatalf("expected state Closed, got %s", cb.State())
+	}
+
+	// A single failure after closing must not reopen on stale window data.
+	cb.RecordFailure()
+	if cb.State() != CircuitClosed {
+		t.Errorf("expected state Closed below minimum requests, got %s", cb.State())
+	}
+}
+
+func TestCircuitBreakerOnStateChange(t *testing.T) {
+	type transition struct {
+		from, to CircuitState
+		stats    CircuitBreakerStats
+	}
+	var transitions []transition
+
+	var cb *CircuitBreaker
+	cb = NewCircuitBreaker(&CircuitBreakerConfig{
+		FailureThreshold: 2,
+		SuccessThreshold: 1,
+		Timeout:          10 * time.Millisecond,
+		Name:             "payment",
+		OnStateChange: func(from, to CircuitState, stats CircuitBreakerStats) {
+			// Hooks run outside the lock and may inspect the breaker.
+			if cb.State() != to {
+				t.Errorf("expected State() %s inside hook, got %s", to, cb.State())
+			}
+			transitions = append(transitions, transition{from: from, to: to, stats: stats})
+		},
+	})
+
+	cb.RecordFailure()
+	if len(transitions) != 0 {
+		t.Fatalf("expected no transition below threshold, got %d", len(transitions))
+	}
+
+	cb.RecordFailure()
+	time.Sleep(15 * time.Millisecond)
+	cb.Allow()
+	cb.RecordSuccess()
+	cb.Reset()
+
+	expected := []struct{ from, to CircuitState }{
+		{CircuitClosed, CircuitOpen},
+		{CircuitOpen, CircuitHalfOpen},
+		{CircuitHalfOpen, CircuitClosed},
+	}
+	if len(transitions) != len(expected) {
+		t.Fatalf("expected %d transitions, got %d", len(expected), len(transitions))
+	}
+	for i, e := range expected {
+		if transitions[i].from != e.from || transitions[i].to != e.to {
+			t.Errorf("transition %d: expected %s->%s, got %s->%s",
+				i, e.from, e.to, transitions[i].from, transitions[i].to)
+		}
+		if transitions[i].stats.State != e.to {
+			t.Errorf("transition %d: unexpected stats %+v", i, transitions[i].stats)
+		}
+	}
+	if transitions[0].stats.ConsecutiveFailures != 2 {
+		t.Errorf("expected 2 consecutive failures in open stats, got %d", transitions[0].stats.ConsecutiveFailures)
+	}
+}
+
+func TestCircuitBreakerSubscribe(t *testing.T) {
+	cb := NewCircuitBreaker(&CircuitBreakerConfig{
+		FailureThreshold: 1,
+		SuccessThreshold: 1,
+		Timeout:          30 * time.Second,
+		Name:             "payment",
+	})
+
+	events, cancel := cb.Subscribe(1)
+
+	cb.RecordFailure()
+
+	select {
+	case change := <-events:
+		if change.Name != "payment" || change.From != CircuitClosed || change.To != CircuitOpen {
+			t.Errorf("unexpected change: %+v", change)
+		}
+		if change.Time.IsZero() {
+			t.Error("expected change time to be set")
+		}
+	default:
+		t.Fatal("expected a state change event")
+	}
+
+	// A full buffer drops events instead of blocking.
+	cb.Reset()
+	cb.RecordFailure()
+	if len(events) != 1 {
+		t.Errorf("expected 1 buffered event, got %d", len(events))
+	}
+
+	cancel()
+	cancel()
+
+	for range events {
+	}
+	cb.Reset()
+}
+
+func TestClientSubscribeCircuitBreaker(t *testing.T) {
+	t.Run("without circuit breaker", func(t *testing.T) {
+		client, _ := NewClient(&Config{
+			BaseURL:        "http://localhost:8080",
+			Timeout:        30 * time.Second,
+			RequestTimeout: 10 * time.Second,
+		}, nil)
+
+		events, cancel := client.SubscribeCircuitBreaker(1)
+		defer cancel()
+		if events != nil {
+			t.Error("expected nil channel without circuit breaker")
+		}
+	})
+
+	t.Run("defaults name to service", func(t *testing.T) {
+		client, _ := NewClient(&Config{
+			BaseURL:        "http://payment-service:8080",
+			Timeout:        30 * time.Second,
+			RequestTimeout: 10 * time.Second,
+			CircuitBreaker: &CircuitBreakerConfig{
+				FailureThreshold: 1,
+				SuccessThreshold: 1,
+				Timeout:          time.Minute,
+			},
+		}, nil)
+
+		events, cancel := client.SubscribeCircuitBreaker(1)
+		defer cancel()
+
+		client.circuitBreaker.RecordFailure()
+
+		change := <-events
+		if change.Name != client.serviceName {
+			t.Errorf("expected name %q, got %q", client.serviceName, change.Name)
+		}
+	})
+}
+
+func TestClientSlowCallTripsCircuitBreaker(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		time.Sleep(30 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{
+		BaseURL:        server.URL,
+		Timeout:        30 * time.Second,
+		RequestTimeout: 10 * time.Second,
+		CircuitBreaker: &CircuitBreakerConfig{
+			FailureThreshold: 1,
+			SuccessThreshold: 1,
+			Timeout:          time.Minute,
+			SlowCallDuration: 10 * time.Millisecond,
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := client.Get(context.Background(), "/test").Do(); err != nil {
+		t.Fatalf("expected slow call to succeed, got %v", err)
+	}
+
+	_, err = client.Get(context.Background(), "/test").Do()
+	if !IsCircuitOpen(err) {
+		t.Fatalf("expected circuit open error, got %v", err)
+	}
+	if atomic.LoadInt32(&requests) != 1 {
+		t.Errorf("expected 1 request to reach the server, got %d", requests)
+	}
+
+	stats := client.CircuitBreakerStats()
+	if stats.SlowCalls != 1 {
+		t.Errorf("expected 1 slow call, got %d", stats.SlowCalls)
+	}
+}
@@ -19,11 +19,27 @@ import (
 // It provides connection pooling, retry logic, circuit breaker, and request tracing.
 type Client struct {
 	httpClient     *http.Client
+	streamClient   *http.Client
 	baseURL        string
+	balancer       *balancer
+	failover       *failover
 	logger         *logging.Logger
 	retryer        *Retryer
 	circuitBreaker *CircuitBreaker
+	bulkhead       *Bulkhead
+	rateLimiter    *RateLimiter
 	serviceName    string
+	timeout        time.Duration
+	tracing        *tracing
+	metrics        Metrics
+	idempotency    *idempotency
+	cache          *responseCache
+	coalescer      *coalescer
+	hedger         *hedger
+	responseLimit  int64
+	envelope       string
+	handler        Handler
+	attemptHandler Handler
 }
 
 // NewClient creates a new Client with the given configuration.
@@ -60,22 +76,95 @@ func NewClient(cfg *Config, logger *logging.Logger) (*Client, error) {
 	}
 
 	// Create circuit breaker if configured.
+	// Extract service name from base URL for logging.
+	serviceName := extractServiceName(cfg.BaseURL)
+
+	metrics := cfg.Metrics
+	if metrics == nil {
+		metrics = NopMetrics{}
+	}
+
 	var circuitBreaker *CircuitBreaker
 	if cfg.CircuitBreaker != nil {
-		circuitBreaker = NewCircuitBreaker(cfg.CircuitBreaker)
+		cbConfig := *cfg.CircuitBreaker
+		if cbConfig.Name == "" {
+			cbConfig.Name = serviceName
+		}
+		cbConfig.OnStateChange = circuitStateRecorder(metrics, serviceName, cbConfig.OnStateChange)
+		circuitBreaker = NewCircuitBreaker(&cbConfig)
+		metrics.SetCircuitState(serviceName, circuitBreaker.State())
 	}
 
-	// Extract service name from base URL for logging.
-	serviceName := extractServiceName(cfg.BaseURL)
+	var bulkhead *Bulkhead
+	if cfg.Bulkhead != nil {
+		bulkhead = NewBulkhead(cfg.Bulkhead)
+	}
 
-	return &Client{
+	var rateLimiter *RateLimiter
+	if cfg.RateLimit != nil {
+		rlConfig := *cfg.RateLimit
+		if rlConfig.Name == "" {
+			rlConfig.Name = serviceName
+		}
+		rateLimiter = NewRateLimiter(&rlConfig)
+	}
+
+	baseURL := strings.TrimSuffix(cfg.BaseURL, "/")
+
+	var lb *balancer
+	if cfg.LoadBalancer != nil {
+		base, err := url.Parse(baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base URL: %w", err)
+		}
+		lb = newBalancer(cfg.LoadBalancer, serviceName, base)
+	}
+
+	var fo *failover
+	if cfg.Failover != nil {
+		primary, err := parseEndpoint(baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base URL: %w", err)
+		}
+		fo = newFailover(cfg.Failover, primary, circuitBreaker, cfg.CircuitBreaker, metrics, serviceName)
+	}
+
+	c := &Client{
 		httpClient:     httpClient,
-		baseURL:        strings.TrimSuffix(cfg.BaseURL, "/"),
+		streamClient:   &http.Client{Transport: transport},
+		baseURL:        baseURL,
+		balancer:       lb,
+		failover:       fo,
 		logger:         logger,
 		retryer:        NewRetryer(cfg.Retry),
 		circuitBreaker: circuitBreaker,
+		bulkhead:       bulkhead,
+		rateLimiter:    rateLimiter,
 		serviceName:    serviceName,
-	}, nil
+		timeout:        cfg.Timeout,
+		tracing:        newTracing(cfg.Tracing),
+		metrics:        metrics,
+		idempotency:    newIdempotency(cfg.Idempotency),
+		cache:          newResponseCache(cfg.Cache),
+		coalescer:      newCoalescer(cfg.Coalescing),
+		hedger:         newHedger(cfg.Hedging),
+		responseLimit:  cfg.MaxResponseSize,
+		envelope:       cfg.ResponseEnvelope,
+	}
+	// Build the middleware chains. The built-in middleware for features enabled by the
+	// config always run, inside the custom middleware.
+	middleware := cfg.Middleware
+	if middleware == nil {
+		middleware = DefaultMiddleware()
+	}
+	c.handler = Chain(Chain(c.executeWithRetry, builtinMiddleware()...), middleware...)
+	attemptMiddleware := cfg.AttemptMiddleware
+	if attemptMiddleware == nil {
+		attemptMiddleware = DefaultAttemptMiddleware()
+	}
+	c.attemptHandler = Chain(Chain(c.executeAttempt, builtinAttemptMiddleware()...), attemptMiddleware...)
+
+	return c, nil
 }
 
 // extractServiceName extracts a service name from a URL for logging purposes.
@@ -87,186 +176,281 @@ func extractServiceName(baseURL string) string {
 	return u.Host
 }
 
+// circuitStateRecorder returns an OnStateChange hook that records every state change of
+// the service circuit breaker in metrics, then calls next, if any.
+func circuitStateRecorder(metrics Metrics, service string, next func(from, to CircuitState, stats CircuitBreakerStats)) func(from, to CircuitState, stats CircuitBreakerStats) {
+	return func(from, to CircuitState, stats CircuitBreakerStats) {
+		metrics.SetCircuitState(service, to)
+		if next != nil {
+			next(from, to, stats)
+		}
+	}
+}
+
 // ErrBodyNotReplayable is returned when a request with a body cannot be retried
 // because the body cannot be recreated.
 var ErrBodyNotReplayable = fmt.Errorf("request body is not replayable for retries")
 
-// requestState holds state for a request execution.
-type requestState struct {
-	hasBody       bool
-	canReplayBody bool
-	startTime     time.Time
+// callOptions holds per-call options set by the Request builder.
+type callOptions struct {
+	route          string
+	template       bool
+	retryer        *Retryer
+	timeout        time.Duration
+	bypassBreaker  bool
+	bypassCache    bool
+	idempotencyKey string
+	idempotent     bool
+	hedge          bool
+	stream         bool
+	subscription   bool
+	responseLimit  int64
 }
 
-// Do executes an HTTP request with retry logic and circuit breaker.
+// doRoute is the route of calls made with Client.Do. Their path may hold IDs, so it is
+// not used as a metrics label.
+const doRoute = "other"
+
+// Do executes an HTTP request through the middleware chain with retry logic.
+// The call is logged, measured and traced with the route "other".
 func (c *Client) Do(ctx context.Context, req *http.Request) (*Response, error) {
-	state := &requestState{
-		hasBody:       req.Body != nil && req.Body != http.NoBody,
-		canReplayBody: req.GetBody != nil,
-		startTime:     time.Now(),
-	}
+	return c.do(ctx, req, callOptions{route: doRoute})
+}
+
+// do executes an HTTP request with the given call options.
+func (c *Client) do(ctx context.Context, req *http.Request, opts callOptions) (*Response, error) {
+	startTime := time.Now()
 
-	// Check circuit breaker.
-	if c.circuitBreaker != nil && !c.circuitBreaker.Allow() {
-		c.logRequest(ctx, req.Method, req.URL.String(), 0, time.Since(state.startTime), ErrCircuitOpen(c.serviceName))
-		return nil, ErrCircuitOpen(c.serviceName)
+	retryer := opts.retryer
+	if retryer == nil {
+		retryer = c.retryer
 	}
 
 	// Check if request body can be replayed for retries.
-	if state.hasBody && !state.canReplayBody && c.retryer.MaxRetries() > 0 {
-		c.logRequest(ctx, req.Method, req.URL.String(), 0, time.Since(state.startTime), ErrBodyNotReplayable)
+	hasBody := req.Body != nil && req.Body != http.NoBody
+	if hasBody && req.GetBody == nil && retryer.MaxRetries() > 0 {
+		c.logRequest(ctx, req.Method, req.URL.String(), 0, time.Since(startTime), ErrBodyNotReplayable)
 		return nil, ErrBodyNotReplayable
 	}
 
-	c.addTracingHeaders(ctx, req)
-	c.logRequestStart(ctx, req)
-
-	return c.executeWithRetry(ctx, req, state)
-}
-
-// attemptResult holds the result of a single request attempt.
-type attemptResult struct {
-	statusCode int
-	headers    http.Header
-	body       []byte
-	retry      bool
+	// Bound the whole call, including retries and backoff, by the call timeout.
+	// Streamed calls are only bounded by WithTimeout, which then bounds reading the body
+	// too, so the timeout ends when it is closed. Subscriptions stay open until they are closed.
+	timeout := c.timeout
+	if opts.stream || opts.timeout > 0 {
+		timeout = opts.timeout
+	}
+	cancel := context.CancelFunc(func() {})
+	if timeout > 0 && !opts.subscription {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+
+	// The key is set once per logical call so every attempt carries the same key.
+	idempotencyKey := c.idempotency.apply(req, opts.idempotencyKey)
+
+	ctx = withCallInfo(ctx, &CallInfo{
+		Service:        c.serviceName,
+		Route:          opts.route,
+		template:       opts.template,
+		StartTime:      startTime,
+		client:         c,
+		retryer:        retryer,
+		breaker:        c.circuitBreaker,
+		bypassBreaker:  opts.bypassBreaker,
+		bypassCache:    opts.bypassCache,
+		hedge:          opts.hedge,
+		stream:         opts.stream,
+		responseLimit:  opts.responseLimit,
+		IdempotencyKey: idempotencyKey,
+		Idempotent:     opts.idempotent || idempotencyKey != "" || IsIdempotentMethod(req.Method),
+	})
+
+	resp, err := c.handler(ctx, req)
+	if err == nil && resp.stream != nil {
+		resp.stream = &cancelOnClose{ReadCloser: resp.stream, cancel: cancel}
+		return resp, nil
+	}
+	cancel()
+	return resp, err
 }
 
 // executeWithRetry executes the request with retry logic.
-func (c *Client) executeWithRetry(ctx context.Context, req *http.Request, state *requestState) (*Response, error) {
+// It is the innermost handler of the call middleware chain.
+func (c *Client) executeWithRetry(ctx context.Context, req *http.Request) (*Response, error) {
+	info := CallInfoFromContext(ctx)
+	retryer := info.retryer
+
+	if budget := retryer.Budget(); budget != nil {
+		budget.RecordRequest()
+	}
+
 	var lastErr error
 
-	for attempt := 0; attempt <= c.retryer.MaxRetries(); attempt++ {
+	for attempt := 0; attempt <= retryer.MaxRetries(); attempt++ {
 		if err := ctx.Err(); err != nil {
-			c.logRequest(ctx, req.Method, req.URL.String(), 0, time.Since(state.startTime), err)
 			return nil, ErrTimeoutWrap("context cancelled", err)
 		}
 
-		result, err := c.executeAttempt(ctx, req, state, attempt)
+		info.attempts = attempt + 1
+		attemptReq, err := newAttemptRequest(ctx, req, info, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.sendAttempt(attemptReq, info)
 		if err != nil {
 			lastErr = err
+			if IsRateLimitExceeded(err) || IsNoHealthyEndpoint(err) {
+				// Local rejections are returned as is; a retry would be rejected the same way.
+				return nil, err
+			}
+			if IsResponseTooLarge(err) {
+				// The upstream would most likely send the same body again.
+				return nil, err
+			}
+			if !retryer.ShouldRetryRequest(attemptReq, nil, err, attempt) {
+				break
+			}
+			wait := retryer.WaitDuration(nil, attempt)
+			if !hasTimeLeft(ctx, wait) {
+				return nil, ErrTimeoutWrap("not enough time left to retry", err)
+			}
+			if !c.allowRetry(ctx, req, retryer) {
+				return nil, ErrRetryBudgetExhausted(c.serviceName, err)
+			}
+			if waitErr := c.waitForRetry(ctx, retryer, req, nil, attempt, wait, err); waitErr != nil {
+				return nil, waitErr
+			}
 			continue
 		}
-		if result.retry {
+
+		// Check if should retry based on status code.
+		httpResp := resp.httpResponse()
+		if retryer.ShouldRetryRequest(attemptReq, httpResp, nil, attempt) {
+			// Return the response as is if the call deadline ends during the backoff.
+			wait := retryer.WaitDuration(httpResp, attempt)
+			if !hasTimeLeft(ctx, wait) {
+				return resp, nil
+			}
+			if !c.allowRetry(ctx, req, retryer) {
+				return nil, ErrRetryBudgetExhausted(c.serviceName, MapHTTPStatus(resp.StatusCode, resp.Body))
+			}
+			if waitErr := c.waitForRetry(ctx, retryer, req, httpResp, attempt, wait, fmt.Errorf("status %d", resp.StatusCode)); waitErr != nil {
+				return nil, waitErr
+			}
 			continue
 		}
 
-		return c.handleResponse(ctx, req, result, state)
+		return resp, nil
 	}
 
-	return c.handleRetriesExhausted(ctx, req, lastErr, state)
+	if lastErr != nil {
+		return nil, ErrTimeoutWrap("all retries exhausted", lastErr)
+	}
+	return nil, ErrTimeout("all retries exhausted")
 }
 
-// executeAttempt executes a single request attempt.
-func (c *Client) executeAttempt(ctx context.Context, req *http.Request, state *requestState, attempt int) (*attemptResult, error) {
-	reqCopy := req.Clone(ctx)
+// newAttemptRequest clones the request for one attempt with its own CallInfo and body.
+func newAttemptRequest(ctx context.Context, req *http.Request, info *CallInfo, attempt int) (*http.Request, error) {
+	attemptInfo := *info
+	attemptInfo.Attempt = attempt
+	attemptReq := req.Clone(withCallInfo(ctx, &attemptInfo))
 
 	// Recreate the body for each attempt.
-	if state.hasBody && state.canReplayBody {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody != nil {
 		newBody, err := req.GetBody()
 		if err != nil {
-			c.logRequest(ctx, req.Method, req.URL.String(), 0, time.Since(state.startTime), err)
 			return nil, fmt.Errorf("failed to recreate request body: %w", err)
 		}
-		reqCopy.Body = newBody
+		attemptReq.Body = newBody
 	}
 
-	resp, err := c.httpClient.Do(reqCopy)
-	if err != nil {
-		if reqCopy.Body != nil {
-			_ = reqCopy.Body.Close()
-		}
-		return c.handleRequestError(ctx, req, err, state, attempt)
+	return attemptReq, nil
+}
+
+// sendAttempt sends one attempt, hedged if enabled for the call, and records its duration.
+func (c *Client) sendAttempt(attemptReq *http.Request, info *CallInfo) (*Response, error) {
+	start := time.Now()
+	defer func() { info.attemptDuration = time.Since(start) }()
+
+	// Hedging cancels the requests it does not use, which would end a streamed body.
+	if c.hedger != nil && info.hedge && info.Idempotent && !info.stream {
+		return c.executeHedged(attemptReq.Context(), attemptReq)
 	}
-	defer resp.Body.Close()
+	return c.attemptHandler(attemptReq.Context(), attemptReq)
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		c.recordResult(false)
-		c.logRequest(ctx, req.Method, req.URL.String(), resp.StatusCode, time.Since(state.startTime), err)
-		return nil, ErrBadGatewayWrap("failed to read response body", err)
+// allowRetry withdraws a retry from the retry budget, if any, and records exhaustion.
+func (c *Client) allowRetry(ctx context.Context, req *http.Request, retryer *Retryer) bool {
+	budget := retryer.Budget()
+	if budget == nil || budget.AllowRetry() {
+		return true
 	}
 
-	// Check if should retry based on status code.
-	if c.retryer.ShouldRetry(resp, nil, attempt) {
-		c.logRetry(ctx, req.Method, req.URL.String(), attempt, fmt.Errorf("status %d", resp.StatusCode))
-		if waitErr := c.retryer.Wait(ctx, resp, attempt); waitErr != nil {
-			c.logRequest(ctx, req.Method, req.URL.String(), resp.StatusCode, time.Since(state.startTime), waitErr)
-			return nil, ErrTimeoutWrap("retry wait cancelled", waitErr)
-		}
-		return &attemptResult{retry: true}, nil
+	if info := CallInfoFromContext(ctx); info != nil {
+		c.metrics.IncRetryBudgetExhausted(info.labels(req.Method))
 	}
+	return false
+}
 
-	return &attemptResult{
-		statusCode: resp.StatusCode,
-		headers:    resp.Header,
-		body:       body,
-	}, nil
+// hasTimeLeft reports whether the context deadline, if any, is more than d away.
+func hasTimeLeft(ctx context.Context, d time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > d
 }
 
-// handleRequestError handles errors from httpClient.Do.
-func (c *Client) handleRequestError(ctx context.Context, req *http.Request, err error, state *requestState, attempt int) (*attemptResult, error) {
-	if c.retryer.ShouldRetry(nil, err, attempt) {
-		c.logRetry(ctx, req.Method, req.URL.String(), attempt, err)
-		if waitErr := c.retryer.Wait(ctx, nil, attempt); waitErr != nil {
-			c.logRequest(ctx, req.Method, req.URL.String(), 0, time.Since(state.startTime), waitErr)
-			return nil, ErrTimeoutWrap("retry wait cancelled", waitErr)
-		}
-		return &attemptResult{retry: true}, nil
+// waitForRetry logs and traces a retry, then waits for the backoff duration.
+func (c *Client) waitForRetry(ctx context.Context, retryer *Retryer, req *http.Request, resp *http.Response, attempt int, wait time.Duration, reason error) error {
+	c.logRetry(ctx, req.Method, req.URL.String(), attempt, retryer.MaxRetries()+1, reason)
+	addRetryEvent(ctx, attempt, wait, reason)
+	if info := CallInfoFromContext(ctx); info != nil {
+		c.metrics.IncRetry(info.labels(req.Method), retryReason(resp))
 	}
 
-	c.recordResult(false)
-	c.logRequest(ctx, req.Method, req.URL.String(), 0, time.Since(state.startTime), err)
-	return nil, ErrTimeoutWrap("request failed", err)
-}
-
-// handleResponse processes a successful response.
-func (c *Client) handleResponse(ctx context.Context, req *http.Request, result *attemptResult, state *requestState) (*Response, error) {
-	response := &Response{
-		StatusCode: result.statusCode,
-		Headers:    result.headers,
-		Body:       result.body,
+	if err := sleepContext(ctx, wait); err != nil {
+		return ErrTimeoutWrap("retry wait cancelled", err)
 	}
+	return nil
+}
 
-	isSuccess := result.statusCode >= 200 && result.statusCode < 500
-	c.recordResult(isSuccess)
+// executeAttempt executes a single request attempt and buffers the response body.
+// It is the innermost handler of the attempt middleware chain.
+func (c *Client) executeAttempt(ctx context.Context, req *http.Request) (*Response, error) {
+	c.setTimeoutHeader(req)
 
-	var logErr error
-	if result.statusCode >= 400 {
-		logErr = MapHTTPStatus(result.statusCode, result.body)
+	if c.balancer != nil {
+		return c.executeBalanced(ctx, req)
 	}
-	c.logRequest(ctx, req.Method, req.URL.String(), result.statusCode, time.Since(state.startTime), logErr)
-
-	return response, nil
+	return c.send(req)
 }
 
-// handleRetriesExhausted handles the case when all retries are exhausted.
-func (c *Client) handleRetriesExhausted(ctx context.Context, req *http.Request, lastErr error, state *requestState) (*Response, error) {
-	c.recordResult(false)
-	if lastErr != nil {
-		c.logRequest(ctx, req.Method, req.URL.String(), 0, time.Since(state.startTime), lastErr)
-		return nil, ErrTimeoutWrap("all retries exhausted", lastErr)
+// send sends the request and buffers the response body, unless the call is streamed.
+func (c *Client) send(req *http.Request) (*Response, error) {
+	info := CallInfoFromContext(req.Context())
+	if info != nil && info.stream {
+		return c.sendStream(req, info)
 	}
 
-	c.logRequest(ctx, req.Method, req.URL.String(), 0, time.Since(state.startTime), nil)
-	return nil, ErrTimeout("all retries exhausted")
-}
-
-// recordResult records the result for the circuit breaker.
-func (c *Client) recordResult(success bool) {
-	if c.circuitBreaker == nil {
-		return
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
 	}
+	defer resp.Body.Close()
 
-	if success {
-		c.circuitBreaker.RecordSuccess()
-	} else {
-		c.circuitBreaker.RecordFailure()
+	body, err := readResponseBody(resp.Body, c.bodyLimit(info))
+	if err != nil {
+		return nil, err
 	}
+
+	return &Response{
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Header,
+		Body:       body,
+	}, nil
 }
 
 // addTracingHeaders adds X-Request-ID and X-Correlation-ID headers from context.
-func (c *Client) addTracingHeaders(ctx context.Context, req *http.Request) {
+func addTracingHeaders(ctx context.Context, req *http.Request) {
 	if requestID := txcontext.RequestID(ctx); requestID != "" {
 		req.Header.Set(txcontext.HeaderRequestID, requestID)
 	}
@@ -282,11 +466,17 @@ func (c *Client) logRequestStart(ctx context.Context, req *http.Request) {
 		return
 	}
 
-	c.logger.DebugContext(ctx, "http request started",
+	attrs := []any{
 		"method", req.Method,
 		"url", req.URL.String(),
 		"service", c.serviceName,
-	)
+	}
+
+	if info := CallInfoFromContext(ctx); info != nil && info.Route != "" {
+		attrs = append(attrs, "route", info.Route)
+	}
+
+	c.logger.DebugContext(ctx, "http request started", attrs...)
 }
 
 // logRequest logs a completed request.
@@ -302,6 +492,15 @@ func (c *Client) logRequest(ctx context.Context, method, reqURL string, statusCo
 		"duration_ms", duration.Milliseconds(),
 	}
 
+	if info := CallInfoFromContext(ctx); info != nil {
+		if info.Route != "" {
+			attrs = append(attrs, "route", info.Route)
+		}
+		if info.endpoint != "" {
+			attrs = append(attrs, "endpoint", info.endpoint)
+		}
+	}
+
 	if statusCode > 0 {
 		attrs = append(attrs, "status", statusCode)
 	}
@@ -321,7 +520,7 @@ func (c *Client) logRequest(ctx context.Context, method, reqURL string, statusCo
 }
 
 // logRetry logs a retry attempt.
-func (c *Client) logRetry(ctx context.Context, method, reqURL string, attempt int, err error) {
+func (c *Client) logRetry(ctx context.Context, method, reqURL string, attempt, maxAttempts int, err error) {
 	if c.logger == nil {
 		return
 	}
@@ -331,36 +530,40 @@ func (c *Client) logRetry(ctx context.Context, method, reqURL string, attempt in
 		"url", reqURL,
 		"service", c.serviceName,
 		"attempt", attempt+1,
-		"max_attempts", c.retryer.MaxRetries()+1,
+		"max_attempts", maxAttempts,
 		"error", err.Error(),
 	)
 }
 
 // Request methods.
+//
+// The path can be a template with {name} placeholders filled in by Param options:
+//
+//	client.Get(ctx, "/rides/{id}", base.Param("id", rideID))
 
 // Get creates a GET request.
-func (c *Client) Get(ctx context.Context, path string) *Request {
-	return c.newRequest(ctx, http.MethodGet, path, nil)
+func (c *Client) Get(ctx context.Context, path string, opts ...RequestOption) *Request {
+	return applyOptions(c.newRequest(ctx, http.MethodGet, path, nil), opts)
 }
 
 // Post creates a POST request with a JSON body.
-func (c *Client) Post(ctx context.Context, path string, body any) *Request {
-	return c.newRequest(ctx, http.MethodPost, path, body)
+func (c *Client) Post(ctx context.Context, path string, body any, opts ...RequestOption) *Request {
+	return applyOptions(c.newRequest(ctx, http.MethodPost, path, body), opts)
 }
 
 // Put creates a PUT request with a JSON body.
-func (c *Client) Put(ctx context.Context, path string, body any) *Request {
-	return c.newRequest(ctx, http.MethodPut, path, body)
+func (c *Client) Put(ctx context.Context, path string, body any, opts ...RequestOption) *Request {
+	return applyOptions(c.newRequest(ctx, http.MethodPut, path, body), opts)
 }
 
 // Patch creates a PATCH request with a JSON body.
-func (c *Client) Patch(ctx context.Context, path string, body any) *Request {
-	return c.newRequest(ctx, http.MethodPatch, path, body)
+func (c *Client) Patch(ctx context.Context, path string, body any, opts ...RequestOption) *Request {
+	return applyOptions(c.newRequest(ctx, http.MethodPatch, path, body), opts)
 }
 
 // Delete creates a DELETE request.
-func (c *Client) Delete(ctx context.Context, path string) *Request {
-	return c.newRequest(ctx, http.MethodDelete, path, nil)
+func (c *Client) Delete(ctx context.Context, path string, opts ...RequestOption) *Request {
+	return applyOptions(c.newRequest(ctx, http.MethodDelete, path, nil), opts)
 }
 
 // newRequest creates a new Request.
@@ -384,7 +587,9 @@ type Request struct {
 	path    string
 	headers http.Header
 	query   url.Values
+	params  map[string]string
 	body    any
+	opts    callOptions
 	err     error
 }
 
@@ -416,14 +621,118 @@ func (r *Request) WithBody(body any) *Request {
 	return r
 }
 
+// WithRetry overrides the client retry configuration for this request.
+// Zero values take the defaults; use WithNoRetry to disable retries.
+// If cfg has no Budget, the client retry budget still applies.
+func (r *Request) WithRetry(cfg RetryConfig) *Request {
+	cfg = cfg.WithDefaults()
+	if err := cfg.Validate(); err != nil {
+		r.err = fmt.Errorf("invalid retry config: %w", err)
+		return r
+	}
+	if cfg.Budget == nil {
+		cfg.Budget = r.client.retryer.Budget()
+	}
+	r.opts.retryer = NewRetryer(cfg)
+	return r
+}
+
+// WithNoRetry disables retries for this request.
+// Use it for non-idempotent calls that must not be sent twice.
+func (r *Request) WithNoRetry() *Request {
+	r.opts.retryer = noRetryer
+	return r
+}
+
+// WithTimeout sets the total timeout for this request, including retries.
+// Each attempt is still bounded by the client RequestTimeout.
+func (r *Request) WithTimeout(d time.Duration) *Request {
+	if d <= 0 {
+		r.err = fmt.Errorf("timeout must be positive")
+		return r
+	}
+	r.opts.timeout = d
+	return r
+}
+
+// WithIdempotencyKey sets the idempotency key sent with every attempt of this request.
+// Servers use it to deduplicate retried mutations.
+func (r *Request) WithIdempotencyKey(key string) *Request {
+	r.opts.idempotencyKey = key
+	return r
+}
+
+// WithIdempotent marks the request as safe to send more than once, such as a POST
+// that only queries data. Idempotent requests are retried and hedged like GET requests.
+func (r *Request) WithIdempotent() *Request {
+	r.opts.idempotent = true
+	return r
+}
+
+// WithHedging enables hedged requests for this request if the client has hedging configured.
+// Only idempotent requests are hedged.
+func (r *Request) WithHedging() *Request {
+	r.opts.hedge = true
+	return r
+}
+
+// WithBreakerBypass sends this request even if the circuit breaker is open.
+// The outcome is not recorded by the circuit breaker.
+func (r *Request) WithBreakerBypass() *Request {
+	r.opts.bypassBreaker = true
+	return r
+}
+
+// WithCacheBypass fetches a fresh response for this request instead of a cached one.
+// The fresh response still replaces the cached one.
+func (r *Request) WithCacheBypass() *Request {
+	r.opts.bypassCache = true
+	return r
+}
+
+// WithMaxResponseSize sets the maximum size of the response body for this request,
+// overriding the client MaxResponseSize. For a streamed request it also limits the
+// streamed body, which is otherwise unlimited.
+func (r *Request) WithMaxResponseSize(n int64) *Request {
+	if n <= 0 {
+		r.err = fmt.Errorf("max response size must be positive")
+		return r
+	}
+	r.opts.responseLimit = n
+	return r
+}
+
 // Do executes the request and returns the response.
 func (r *Request) Do() (*Response, error) {
+	req, err := r.build()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.client.do(r.ctx, req, r.callOptions())
+}
+
+// callOptions returns the call options of the request. Requests with path parameters
+// are labelled by their path template, others by their path.
+func (r *Request) callOptions() callOptions {
+	opts := r.opts
+	opts.route = r.path
+	opts.template = len(r.params) > 0
+	return opts
+}
+
+// build creates the HTTP request.
+func (r *Request) build() (*http.Request, error) {
 	if r.err != nil {
 		return nil, r.err
 	}
 
 	// Build URL.
-	fullURL := r.client.baseURL + r.path
+	path, err := expandPath(r.path, r.params)
+	if err != nil {
+		return nil, err
+	}
+	fullURL := r.client.baseURL + path
 	if len(r.query) > 0 {
 		fullURL += "?" + r.query.Encode()
 	}
@@ -432,7 +741,6 @@ func (r *Request) Do() (*Response, error) {
 	var bodyReader io.Reader
 	var bodyBytes []byte
 	if r.body != nil {
-		var err error
 		bodyBytes, err = json.Marshal(r.body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
@@ -471,7 +779,7 @@ func (r *Request) Do() (*Response, error) {
 		req.Header.Set("Accept", "application/json")
 	}
 
-	return r.client.Do(r.ctx, req)
+	return req, nil
 }
 
 // Decode executes the request and decodes the response into dest.
@@ -485,14 +793,67 @@ func (r *Request) Decode(dest any) error {
 }
 
 // CircuitBreakerStats returns the circuit breaker stats, or nil if no circuit breaker.
+// With failover, it returns the stats of the active endpoint's circuit breaker and
+// reports the active endpoint.
 func (c *Client) CircuitBreakerStats() *CircuitBreakerStats {
 	if c.circuitBreaker == nil {
 		return nil
 	}
-	stats := c.circuitBreaker.Stats()
+	if c.failover == nil {
+		stats := c.circuitBreaker.Stats()
+		return &stats
+	}
+
+	active := c.failover.current()
+	stats := active.breaker.Stats()
+	stats.ActiveEndpoint = active.url.String()
 	return &stats
 }
 
+// BulkheadStats returns the bulkhead stats, or nil if no bulkhead.
+func (c *Client) BulkheadStats() *BulkheadStats {
+	if c.bulkhead == nil {
+		return nil
+	}
+	stats := c.bulkhead.Stats()
+	return &stats
+}
+
+// RateLimiterStats returns the rate limiter stats, or nil if no rate limiter.
+func (c *Client) RateLimiterStats() *RateLimiterStats {
+	if c.rateLimiter == nil {
+		return nil
+	}
+	stats := c.rateLimiter.Stats()
+	return &stats
+}
+
+// EndpointStats returns the stats of every load balancer endpoint, or nil if no load balancer.
+func (c *Client) EndpointStats() []EndpointStats {
+	if c.balancer == nil {
+		return nil
+	}
+	return c.balancer.stats()
+}
+
+// SubscribeCircuitBreaker subscribes to circuit breaker state changes.
+// With failover, it also receives the changes of the secondary endpoint's circuit breaker,
+// which is named after the secondary host.
+// It returns a nil channel and a no-op cancel function if no circuit breaker is configured.
+func (c *Client) SubscribeCircuitBreaker(buffer int) (<-chan CircuitStateChange, func()) {
+	if c.circuitBreaker == nil {
+		return nil, func() {}
+	}
+
+	breakers := []*CircuitBreaker{c.circuitBreaker}
+	if c.failover != nil {
+		breakers = append(breakers, c.failover.secondary.breaker)
+	}
+
+	ch := make(chan CircuitStateChange, buffer)
+	return ch, subscribeAll(ch, breakers...)
+}
+
 // BaseURL returns the base URL of the client.
 func (c *Client) BaseURL() string {
 	return c.baseURL
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -292,6 +293,48 @@ func TestClientDo(t *testing.T) {
 		}
 	})
 
+	t.Run("releases the half-open probe of a cancelled call", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client, err := NewClient(&Config{
+			BaseURL:        server.URL,
+			Timeout:        30 * time.Second,
+			RequestTimeout: 10 * time.Second,
+			Retry:          RetryConfig{MaxRetries: 0},
+			CircuitBreaker: &CircuitBreakerConfig{
+				FailureThreshold:    1,
+				SuccessThreshold:    1,
+				Timeout:             10 * time.Millisecond,
+				MaxConcurrentProbes: 1,
+			},
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		client.circuitBreaker.RecordFailure()
+		time.Sleep(20 * time.Millisecond)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := client.Get(ctx, "/test").Do(); err == nil {
+			t.Fatal("expected error for cancelled call")
+		}
+		if client.circuitBreaker.State() != CircuitHalfOpen {
+			t.Fatalf("expected half-open circuit, got %s", client.circuitBreaker.State())
+		}
+
+		if _, err := client.Get(context.Background(), "/test").Do(); err != nil {
+			t.Fatalf("expected next probe to be allowed, got %v", err)
+		}
+		if client.circuitBreaker.State() != CircuitClosed {
+			t.Errorf("expected closed circuit, got %s", client.circuitBreaker.State())
+		}
+	})
+
 	t.Run("respects context cancellation", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			time.Sleep(100 * time.Millisecond)
@@ -526,3 +569,307 @@ func TestClientCircuitBreakerStats(t *testing.T) {
 		}
 	})
 }
+
+func TestRequestOverrides(t *testing.T) {
+	newClient := func(t *testing.T, serverURL string, cb *CircuitBreakerConfig) *Client {
+		t.Helper()
+		client, err := NewClient(&Config{
+			BaseURL:        serverURL,
+			Timeout:        30 * time.Second,
+			RequestTimeout: 10 * time.Second,
+			Retry: RetryConfig{
+				MaxRetries:  3,
+				InitialWait: time.Millisecond,
+				MaxWait:     5 * time.Millisecond,
+				Multiplier:  1.0,
+			},
+			CircuitBreaker: cb,
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		return client
+	}
+
+	unavailable := func(calls *int32) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(calls, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+	}
+
+	t.Run("WithNoRetry", func(t *testing.T) {
+		var calls int32
+		server := unavailable(&calls)
+		defer server.Close()
+
+		client := newClient(t, server.URL, nil)
+		_, err := client.Post(context.Background(), "/earnings", map[string]int{"amount": 1}).
+			WithNoRetry().
+			Do()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("expected 1 call, got %d", calls)
+		}
+	})
+
+	t.Run("WithNoRetry allows non-replayable body", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := newClient(t, server.URL, nil)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, io.NopCloser(strings.NewReader("x")))
+
+		if _, err := client.do(context.Background(), req, callOptions{retryer: noRetryer}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("WithRetry", func(t *testing.T) {
+		var calls int32
+		server := unavailable(&calls)
+		defer server.Close()
+
+		client := newClient(t, server.URL, nil)
+		_, err := client.Get(context.Background(), "/test").
+			WithRetry(RetryConfig{MaxRetries: 1, InitialWait: time.Millisecond, MaxWait: time.Millisecond, Multiplier: 1.0}).
+			Do()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls != 2 {
+			t.Errorf("expected 2 calls, got %d", calls)
+		}
+	})
+
+	t.Run("WithRetry applies defaults to a partial config", func(t *testing.T) {
+		var calls int32
+		server := unavailable(&calls)
+		defer server.Close()
+
+		client := newClient(t, server.URL, nil)
+		_, err := client.Get(context.Background(), "/test").
+			WithRetry(RetryConfig{MaxRetries: 2, InitialWait: time.Millisecond, MaxWait: time.Millisecond}).
+			Do()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls != 3 {
+			t.Errorf("expected 3 calls, got %d", calls)
+		}
+	})
+
+	t.Run("WithRetry rejects invalid config", func(t *testing.T) {
+		client := newClient(t, "http://localhost:8080", nil)
+		_, err := client.Get(context.Background(), "/test").
+			WithRetry(RetryConfig{MaxRetries: -1}).
+			Do()
+		if err == nil || !strings.Contains(err.Error(), "invalid retry config") {
+			t.Errorf("expected invalid retry config error, got %v", err)
+		}
+	})
+
+	t.Run("WithTimeout", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := newClient(t, server.URL, nil)
+		start := time.Now()
+		_, err := client.Get(context.Background(), "/test").
+			WithTimeout(20 * time.Millisecond).
+			Do()
+		if err == nil {
+			t.Fatal("expected timeout error")
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("expected call to stop at the timeout, took %v", elapsed)
+		}
+	})
+
+	t.Run("WithTimeout rejects non-positive duration", func(t *testing.T) {
+		client := newClient(t, "http://localhost:8080", nil)
+		_, err := client.Get(context.Background(), "/test").WithTimeout(0).Do()
+		if err == nil || !strings.Contains(err.Error(), "timeout must be positive") {
+			t.Errorf("expected timeout error, got %v", err)
+		}
+	})
+
+	t.Run("WithBreakerBypass", func(t *testing.T) {
+		var calls int32
+		server := unavailable(&calls)
+		defer server.Close()
+
+		client := newClient(t, server.URL, &CircuitBreakerConfig{
+			FailureThreshold: 1,
+			SuccessThreshold: 1,
+			Timeout:          time.Minute,
+		})
+		client.circuitBreaker.RecordFailure()
+
+		_, err := client.Get(context.Background(), "/health").WithNoRetry().Do()
+		if !IsCircuitOpen(err) {
+			t.Fatalf("expected circuit open error, got %v", err)
+		}
+
+		resp, err := client.Get(context.Background(), "/health").
+			WithNoRetry().
+			WithBreakerBypass().
+			Do()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.StatusCode != http.StatusServiceUnavailable || calls != 1 {
+			t.Errorf("expected bypassed call to reach the server, got status %d after %d calls", resp.StatusCode, calls)
+		}
+		if stats := client.CircuitBreakerStats(); stats.ConsecutiveFailures != 1 {
+			t.Errorf("expected bypassed call not to be recorded, got %d failures", stats.ConsecutiveFailures)
+		}
+	})
+}
+
+func TestCallDeadline(t *testing.T) {
+	newClient := func(t *testing.T, serverURL string, timeout time.Duration, retry RetryConfig) *Client {
+		t.Helper()
+		client, err := NewClient(&Config{
+			BaseURL:        serverURL,
+			Timeout:        timeout,
+			RequestTimeout: timeout,
+			Retry:          retry,
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		return client
+	}
+
+	t.Run("caps total time across retries", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			time.Sleep(20 * time.Millisecond)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client := newClient(t, server.URL, 150*time.Millisecond, RetryConfig{
+			MaxRetries:  100,
+			InitialWait: 10 * time.Millisecond,
+			MaxWait:     10 * time.Millisecond,
+			Multiplier:  1.0,
+		})
+
+		start := time.Now()
+		_, _ = client.Get(context.Background(), "/test").Do()
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("expected the call to stop at its deadline, took %v", elapsed)
+		}
+		if n := atomic.LoadInt32(&calls); n >= 100 {
+			t.Errorf("expected retries to stop at the deadline, got %d calls", n)
+		}
+	})
+
+	t.Run("skips retry with backoff beyond the deadline", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client := newClient(t, server.URL, 200*time.Millisecond, RetryConfig{
+			MaxRetries:  3,
+			InitialWait: time.Second,
+			MaxWait:     time.Second,
+			Multiplier:  1.0,
+		})
+
+		start := time.Now()
+		resp, err := client.Get(context.Background(), "/test").Do()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("expected status 503, got %d", resp.StatusCode)
+		}
+		if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+			t.Errorf("expected the retry to be skipped, took %v", elapsed)
+		}
+		if calls != 1 {
+			t.Errorf("expected 1 call, got %d", calls)
+		}
+	})
+
+	t.Run("skips retry after error with backoff beyond the deadline", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		serverURL := server.URL
+		server.Close()
+
+		client := newClient(t, serverURL, 200*time.Millisecond, RetryConfig{
+			MaxRetries:  3,
+			InitialWait: time.Second,
+			MaxWait:     time.Second,
+			Multiplier:  1.0,
+		})
+
+		_, err := client.Get(context.Background(), "/test").Do()
+		if !IsTimeout(err) {
+			t.Errorf("expected timeout error, got %v", err)
+		}
+		if err == nil || !strings.Contains(err.Error(), "not enough time left to retry") {
+			t.Errorf("expected skipped retry, got %v", err)
+		}
+	})
+
+	t.Run("sends remaining time to the server", func(t *testing.T) {
+		var header string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header.Get(HeaderRequestTimeout)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := newClient(t, server.URL, 5*time.Second, DefaultRetryConfig())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if _, err := client.Get(ctx, "/test").Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		ms, err := strconv.Atoi(header)
+		if err != nil {
+			t.Fatalf("expected %s header in milliseconds, got %q", HeaderRequestTimeout, header)
+		}
+		if ms <= 0 || ms > 2000 {
+			t.Errorf("expected remaining time of at most 2000ms, got %d", ms)
+		}
+	})
+
+	t.Run("per-request timeout overrides the client timeout", func(t *testing.T) {
+		var header string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header.Get(HeaderRequestTimeout)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := newClient(t, server.URL, 5*time.Second, DefaultRetryConfig())
+		if _, err := client.Get(context.Background(), "/test").WithTimeout(time.Second).Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if ms, _ := strconv.Atoi(header); ms <= 0 || ms > 1000 {
+			t.Errorf("expected remaining time of at most 1000ms, got %q", header)
+		}
+	})
+}
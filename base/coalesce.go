@@ -0,0 +1,161 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// defaultCoalescingHeaders are the request headers that distinguish coalesced calls by default.
+var defaultCoalescingHeaders = []string{"Accept", "Accept-Language", "Authorization"}
+
+// CoalescingConfig holds request coalescing configuration.
+// Identical GET calls made while one is in flight wait for it instead of being sent,
+// and every caller receives its own copy of the response.
+type CoalescingConfig struct {
+	// Headers are the request headers that must match, in addition to the method and URL,
+	// for calls to be coalesced (default: Accept, Accept-Language and Authorization).
+	Headers []string
+}
+
+// Validate validates the coalescing configuration.
+func (c *CoalescingConfig) Validate() error {
+	for _, header := range c.Headers {
+		if strings.TrimSpace(header) == "" {
+			return fmt.Errorf("header names cannot be empty")
+		}
+	}
+	return nil
+}
+
+// coalescedCall is a call in flight that identical calls wait for.
+type coalescedCall struct {
+	done      chan struct{}
+	resp      *Response
+	err       error
+	cancelled bool
+	waiters   int
+}
+
+// coalescer deduplicates identical concurrent calls.
+type coalescer struct {
+	headers []string
+
+	mu    sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+// newCoalescer creates the coalescer for a client, or returns nil if cfg is nil.
+func newCoalescer(cfg *CoalescingConfig) *coalescer {
+	if cfg == nil {
+		return nil
+	}
+
+	headers := cfg.Headers
+	if len(headers) == 0 {
+		headers = defaultCoalescingHeaders
+	}
+
+	return &coalescer{
+		headers: headers,
+		calls:   make(map[string]*coalescedCall),
+	}
+}
+
+// key returns the key identifying calls that can share a response.
+func (g *coalescer) key(req *http.Request) string {
+	var b strings.Builder
+	b.WriteString(req.Method)
+	b.WriteByte(' ')
+	b.WriteString(req.URL.String())
+	for _, header := range g.headers {
+		b.WriteByte('\n')
+		b.WriteString(http.CanonicalHeaderKey(header))
+		b.WriteString(": ")
+		b.WriteString(strings.Join(req.Header.Values(header), ", "))
+	}
+	return b.String()
+}
+
+// do runs fn unless an identical call is in flight, in which case it waits for that
+// call's result. It reports whether the result came from another call.
+// A caller whose wait is answered by a call cancelled by its own caller runs fn itself.
+func (g *coalescer) do(ctx context.Context, key string, fn func() (*Response, error)) (*Response, bool, error) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		call.waiters++
+		g.mu.Unlock()
+
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, true, ErrTimeoutWrap("coalesced call wait cancelled", ctx.Err())
+		}
+
+		if call.cancelled {
+			resp, err := fn()
+			return resp, false, err
+		}
+		return call.resp.clone(), true, call.err
+	}
+
+	call := &coalescedCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	completed := false
+	defer func() {
+		if !completed {
+			// fn panicked; waiters run the call themselves.
+			call.cancelled = true
+			g.remove(key, call)
+		}
+		close(call.done)
+	}()
+
+	call.resp, call.err = fn()
+	call.cancelled = call.err != nil && ctx.Err() != nil
+	completed = true
+
+	if g.remove(key, call) {
+		return call.resp.clone(), false, call.err
+	}
+	return call.resp, false, call.err
+}
+
+// remove ends the call so that later identical calls are sent again, and reports whether
+// any caller is waiting for its result. No caller can start waiting once it is removed.
+func (g *coalescer) remove(key string, call *coalescedCall) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+	return call.waiters > 0
+}
+
+// CoalescingMiddleware sends only one of several identical concurrent GET calls and gives
+// every caller a copy of its response. It has no effect if the client has no coalescing configured
+// or the call is streamed.
+func CoalescingMiddleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *http.Request) (*Response, error) {
+			info := CallInfoFromContext(ctx)
+			if info == nil || info.client.coalescer == nil || info.stream || req.Method != http.MethodGet {
+				return next(ctx, req)
+			}
+
+			g := info.client.coalescer
+			resp, shared, err := g.do(ctx, g.key(req), func() (*Response, error) {
+				return next(ctx, req)
+			})
+			if shared {
+				info.client.metrics.IncCoalescedCall(info.labels(req.Method))
+			}
+			return resp, err
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalescingConfigValidate(t *testing.T) {
+	if err := (&CoalescingConfig{Headers: []string{"Authorization"}}).Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err := (&CoalescingConfig{Headers: []string{" "}}).Validate()
+	if err == nil || !strings.Contains(err.Error(), "header names cannot be empty") {
+		t.Errorf("expected empty header error, got %v", err)
+	}
+}
+
+// waitForWaiters waits until n callers are waiting for the call with the given key.
+func waitForWaiters(t *testing.T, g *coalescer, key string, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		g.mu.Lock()
+		call := g.calls[key]
+		joined := call != nil && call.waiters >= n
+		g.mu.Unlock()
+		if joined {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d waiters", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestCoalescer(t *testing.T) {
+	t.Run("waiters share the result", func(t *testing.T) {
+		g := newCoalescer(&CoalescingConfig{})
+		release := make(chan struct{})
+		var calls atomic.Int32
+		fn := func() (*Response, error) {
+			calls.Add(1)
+			<-release
+			return &Response{StatusCode: http.StatusOK, Body: []byte("driver")}, nil
+		}
+
+		results := make(chan *Response, 3)
+		go func() {
+			resp, _, _ := g.do(context.Background(), "k", fn)
+			results <- resp
+		}()
+		// Wait for the first call to be in flight before the others join it.
+		for {
+			g.mu.Lock()
+			inFlight := g.calls["k"] != nil
+			g.mu.Unlock()
+			if inFlight {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+		for i := 0; i < 2; i++ {
+			go func() {
+				resp, shared, _ := g.do(context.Background(), "k", fn)
+				if !shared {
+					t.Error("expected a shared result")
+				}
+				results <- resp
+			}()
+		}
+		waitForWaiters(t, g, "k", 2)
+		close(release)
+
+		var responses []*Response
+		for i := 0; i < 3; i++ {
+			responses = append(responses, <-results)
+		}
+		if got := calls.Load(); got != 1 {
+			t.Errorf("expected 1 call, got %d", got)
+		}
+
+		// Every caller gets its own copy.
+		responses[0].Body[0] = 'X'
+		for _, resp := range responses[1:] {
+			if string(resp.Body) != "driver" {
+				t.Errorf("expected an unmodified copy, got %s", resp.Body)
+			}
+		}
+	})
+
+	t.Run("waiter runs the call itself if the first caller cancels", func(t *testing.T) {
+		g := newCoalescer(&CoalescingConfig{})
+		ctx, cancel := context.WithCancel(context.Background())
+		started := make(chan struct{})
+		var calls atomic.Int32
+
+		leaderDone := make(chan struct{})
+		go func() {
+			defer close(leaderDone)
+			_, _, _ = g.do(ctx, "k", func() (*Response, error) {
+				calls.Add(1)
+				close(started)
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+		}()
+		<-started
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var waiterResp *Response
+		var waiterErr error
+		go func() {
+			defer wg.Done()
+			waiterResp, _, waiterErr = g.do(context.Background(), "k", func() (*Response, error) {
+				calls.Add(1)
+				return &Response{StatusCode: http.StatusOK}, nil
+			})
+		}()
+		waitForWaiters(t, g, "k", 1)
+		cancel()
+		<-leaderDone
+		wg.Wait()
+
+		if waiterErr != nil || waiterResp == nil || waiterResp.StatusCode != http.StatusOK {
+			t.Errorf("expected the waiter to succeed, got %v, %v", waiterResp, waiterErr)
+		}
+		if got := calls.Load(); got != 2 {
+			t.Errorf("expected 2 calls, got %d", got)
+		}
+	})
+
+	t.Run("waiter gives up when its context ends", func(t *testing.T) {
+		g := newCoalescer(&CoalescingConfig{})
+		release := make(chan struct{})
+		defer close(release)
+		started := make(chan struct{})
+
+		go func() {
+			_, _, _ = g.do(context.Background(), "k", func() (*Response, error) {
+				close(started)
+				<-release
+				return &Response{StatusCode: http.StatusOK}, nil
+			})
+		}()
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, _, err := g.do(ctx, "k", func() (*Response, error) {
+			t.Error("waiter should not run the call")
+			return nil, nil
+		})
+		if !IsTimeout(err) {
+			t.Errorf("expected timeout error, got %v", err)
+		}
+	})
+}
+
+func TestClientCoalescing(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		_, _ = w.Write([]byte(`{"id":"d1"}`))
+	}))
+	defer server.Close()
+
+	metrics := &recordingMetrics{}
+	client, err := NewClient(&Config{
+		BaseURL:    server.URL,
+		Coalescing: &CoalescingConfig{},
+		Metrics:    metrics,
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	const callers = 5
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(context.Background(), "/drivers/d1").Do()
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if string(resp.Body) != `{"id":"d1"}` {
+				t.Errorf("unexpected body: %s", resp.Body)
+			}
+		}()
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/drivers/d1", nil)
+	req.Header.Set("Accept", "application/json")
+	waitForWaiters(t, client.coalescer, client.coalescer.key(req), callers-1)
+	close(release)
+	wg.Wait()
+
+	if got := hits.Load(); got != 1 {
+		t.Errorf("expected 1 call to the service, got %d", got)
+	}
+	if metrics.coalescedCalls != callers-1 {
+		t.Errorf("expected %d coalesced calls, got %d", callers-1, metrics.coalescedCalls)
+	}
+}
+
+func TestCoalescerKey(t *testing.T) {
+	g := newCoalescer(&CoalescingConfig{})
+	newReq := func(auth string) *http.Request {
+		req, _ := http.NewRequest(http.MethodGet, "http://driver-service/drivers/d1", nil)
+		req.Header.Set("Authorization", auth)
+		req.Header.Set("X-Request-ID", auth)
+		return req
+	}
+
+	if g.key(newReq("Bearer a")) == g.key(newReq("Bearer b")) {
+		t.Error("expected calls with different Authorization headers to have different keys")
+	}
+
+	other := newCoalescer(&CoalescingConfig{Headers: []string{"Accept"}})
+	if other.key(newReq("Bearer a")) != other.key(newReq("Bearer b")) {
+		t.Error("expected headers not configured to be ignored")
+	}
+}
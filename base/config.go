@@ -10,12 +10,15 @@ import (
 // Config holds the configuration for the HTTP client.
 type Config struct {
 	// BaseURL is the base URL for all requests (required).
+	// With a LoadBalancer, it names the service and is replaced by an endpoint for every attempt.
 	BaseURL string
 
-	// Timeout is the total request timeout including retries (default: 30s).
+	// Timeout is the total time budget for a call, including retries and backoff (default: 30s).
+	// Retries whose backoff would exceed the remaining budget are skipped.
 	Timeout time.Duration
 
-	// RequestTimeout is the timeout for a single request attempt (default: 10s).
+	// RequestTimeout is the timeout for a single request attempt (default: a third of Timeout).
+	// Keep it well below Timeout so that retries fit inside the call budget.
 	RequestTimeout time.Duration
 
 	// MaxIdleConns is the maximum number of idle connections (default: 100).
@@ -27,6 +30,15 @@ type Config struct {
 	// IdleConnTimeout is how long idle connections stay open (default: 90s).
 	IdleConnTimeout time.Duration
 
+	// MaxResponseSize is the maximum size of a response body in bytes (default: 10 MiB).
+	// Larger responses fail with a response too large error. Streamed bodies are not limited.
+	MaxResponseSize int64
+
+	// ResponseEnvelope is the JSON field that wraps response bodies, such as "data".
+	// The typed request helpers, such as GetJSON, decode the field's value. If empty,
+	// they decode the whole body.
+	ResponseEnvelope string
+
 	// TLSConfig is the TLS configuration for HTTPS connections.
 	TLSConfig *tls.Config
 
@@ -39,6 +51,53 @@ type Config struct {
 
 	// CircuitBreaker configuration. If nil, circuit breaker is disabled.
 	CircuitBreaker *CircuitBreakerConfig
+
+	// Middleware is applied around every logical call, including all retries.
+	// The first middleware is the outermost. If nil, DefaultMiddleware() is used;
+	// set an empty slice to disable the built-in logging and request ID middleware.
+	// Tracing, metrics, caching, coalescing, the bulkhead, failover and the circuit breaker
+	// always run inside this middleware when enabled in the config.
+	Middleware []Middleware
+
+	// AttemptMiddleware is applied around every individual attempt, inside the retry loop.
+	// The first middleware is the outermost. If nil, DefaultAttemptMiddleware() is used.
+	// Rate limiting and attempt tracing always run inside this middleware when enabled.
+	AttemptMiddleware []Middleware
+
+	// Tracing configuration. If nil, OpenTelemetry tracing is disabled.
+	Tracing *TracingConfig
+
+	// Metrics records request, retry and circuit breaker metrics (default: NopMetrics).
+	Metrics Metrics
+
+	// Hedging configures hedged requests. If nil, hedging is disabled.
+	Hedging *HedgingConfig
+
+	// Bulkhead limits the number of concurrent calls. If nil, calls are not limited.
+	Bulkhead *BulkheadConfig
+
+	// LoadBalancer spreads requests across several endpoints. If nil, all requests go to BaseURL.
+	LoadBalancer *LoadBalancerConfig
+
+	// Failover sends requests to a secondary deployment while BaseURL is unavailable.
+	// It cannot be combined with LoadBalancer. If nil, requests never fail over.
+	Failover *FailoverConfig
+
+	// RateLimit limits the rate of requests, including retries and hedged requests.
+	// If nil, requests are not rate limited.
+	RateLimit *RateLimitConfig
+
+	// Cache caches GET responses following their Cache-Control, ETag and Last-Modified headers.
+	// If nil, responses are not cached.
+	Cache *CacheConfig
+
+	// Coalescing sends only one of several identical concurrent GET calls and shares its
+	// response. If nil, every call is sent.
+	Coalescing *CoalescingConfig
+
+	// Idempotency configures automatic idempotency keys. If nil, keys are only sent
+	// when set explicitly with Request.WithIdempotencyKey.
+	Idempotency *IdempotencyConfig
 }
 
 // RetryConfig holds retry configuration.
@@ -57,6 +116,13 @@ type RetryConfig struct {
 
 	// Jitter is the random jitter factor to add to backoff (default: 0.1).
 	Jitter float64
+
+	// Policy decides which failed attempts are retried (default: DefaultRetryPolicy()).
+	Policy RetryPolicy
+
+	// Budget limits retries to a fraction of recent requests. It can be shared by
+	// several clients. If nil, retries are only limited by MaxRetries.
+	Budget *RetryBudget
 }
 
 // CircuitBreakerConfig holds circuit breaker configuration.
@@ -74,7 +140,38 @@ type CircuitBreakerConfig struct {
 	MaxConcurrentProbes int
 
 	// Name is an identifier for this circuit breaker (used in logging/metrics).
+	// If empty, the client uses the service name.
 	Name string
+
+	// Mode selects how the circuit decides to open (default: CircuitModeConsecutive).
+	// FailureThreshold is only used in consecutive mode.
+	Mode CircuitBreakerMode
+
+	// WindowSize is the number of calls in the sliding window for CircuitModeCountWindow (default: 100).
+	WindowSize int
+
+	// WindowDuration is the length of the sliding window for CircuitModeTimeWindow (default: 60s).
+	WindowDuration time.Duration
+
+	// MinimumRequests is the number of calls the window must hold before the rates are evaluated (default: 10).
+	MinimumRequests int
+
+	// FailureRateThreshold is the fraction of failed calls, between 0 and 1, that opens the circuit
+	// in window modes (default: 0.5).
+	FailureRateThreshold float64
+
+	// SlowCallRateThreshold is the fraction of slow calls, between 0 and 1, that opens the circuit
+	// in window modes. Zero disables slow-call tripping.
+	SlowCallRateThreshold float64
+
+	// SlowCallDuration is the latency at or above which a call is considered slow.
+	// Slow calls count as failures unless SlowCallRateThreshold is set in a window mode.
+	// Zero disables slow-call detection.
+	SlowCallDuration time.Duration
+
+	// OnStateChange is called after every state transition, outside the breaker lock.
+	// It runs on the request path, so slow work should be handed off to a goroutine.
+	OnStateChange func(from, to CircuitState, stats CircuitBreakerStats)
 }
 
 // DefaultConfig returns a Config with sensible defaults.
@@ -85,6 +182,7 @@ func DefaultConfig() *Config {
 		MaxIdleConns:        100,
 		MaxIdleConnsPerHost: 10,
 		IdleConnTimeout:     90 * time.Second,
+		MaxResponseSize:     DefaultMaxResponseSize,
 		Retry:               DefaultRetryConfig(),
 	}
 }
@@ -128,6 +226,10 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("request timeout cannot exceed total timeout")
 	}
 
+	if c.MaxResponseSize < 0 {
+		return fmt.Errorf("max response size cannot be negative")
+	}
+
 	if err := c.Retry.Validate(); err != nil {
 		return fmt.Errorf("retry config: %w", err)
 	}
@@ -138,6 +240,57 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.Tracing != nil {
+		if err := c.Tracing.Validate(); err != nil {
+			return fmt.Errorf("tracing config: %w", err)
+		}
+	}
+
+	if c.Hedging != nil {
+		if err := c.Hedging.Validate(); err != nil {
+			return fmt.Errorf("hedging config: %w", err)
+		}
+	}
+
+	if c.Bulkhead != nil {
+		if err := c.Bulkhead.Validate(); err != nil {
+			return fmt.Errorf("bulkhead config: %w", err)
+		}
+	}
+
+	if c.RateLimit != nil {
+		if err := c.RateLimit.Validate(); err != nil {
+			return fmt.Errorf("rate limit config: %w", err)
+		}
+	}
+
+	if c.LoadBalancer != nil {
+		if err := c.LoadBalancer.Validate(); err != nil {
+			return fmt.Errorf("load balancer config: %w", err)
+		}
+	}
+
+	if c.Cache != nil {
+		if err := c.Cache.Validate(); err != nil {
+			return fmt.Errorf("cache config: %w", err)
+		}
+	}
+
+	if c.Coalescing != nil {
+		if err := c.Coalescing.Validate(); err != nil {
+			return fmt.Errorf("coalescing config: %w", err)
+		}
+	}
+
+	if c.Failover != nil {
+		if c.LoadBalancer != nil {
+			return fmt.Errorf("failover cannot be combined with a load balancer")
+		}
+		if err := c.Failover.Validate(); err != nil {
+			return fmt.Errorf("failover config: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -172,8 +325,17 @@ func (c *RetryConfig) Validate() error {
 
 // Validate validates the circuit breaker configuration.
 func (c *CircuitBreakerConfig) Validate() error {
-	if c.FailureThreshold <= 0 {
-		return fmt.Errorf("failure threshold must be positive")
+	switch c.Mode {
+	case CircuitModeConsecutive:
+		if c.FailureThreshold <= 0 {
+			return fmt.Errorf("failure threshold must be positive")
+		}
+	case CircuitModeCountWindow, CircuitModeTimeWindow:
+		if err := c.validateWindow(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown mode %d", c.Mode)
 	}
 
 	if c.SuccessThreshold <= 0 {
@@ -184,6 +346,39 @@ func (c *CircuitBreakerConfig) Validate() error {
 		return fmt.Errorf("timeout must be positive")
 	}
 
+	if c.SlowCallDuration < 0 {
+		return fmt.Errorf("slow call duration cannot be negative")
+	}
+
+	return nil
+}
+
+// validateWindow validates the sliding window settings.
+func (c *CircuitBreakerConfig) validateWindow() error {
+	if c.WindowSize < 0 {
+		return fmt.Errorf("window size cannot be negative")
+	}
+
+	if c.WindowDuration < 0 {
+		return fmt.Errorf("window duration cannot be negative")
+	}
+
+	if c.MinimumRequests < 0 {
+		return fmt.Errorf("minimum requests cannot be negative")
+	}
+
+	if c.FailureRateThreshold < 0 || c.FailureRateThreshold > 1.0 {
+		return fmt.Errorf("failure rate threshold must be between 0 and 1")
+	}
+
+	if c.SlowCallRateThreshold < 0 || c.SlowCallRateThreshold > 1.0 {
+		return fmt.Errorf("slow call rate threshold must be between 0 and 1")
+	}
+
+	if c.SlowCallRateThreshold > 0 && c.SlowCallDuration <= 0 {
+		return fmt.Errorf("slow call duration must be positive when slow call rate threshold is set")
+	}
+
 	return nil
 }
 
@@ -196,7 +391,7 @@ func (c *Config) WithDefaults() *Config {
 	}
 
 	if cfg.RequestTimeout == 0 {
-		cfg.RequestTimeout = 10 * time.Second
+		cfg.RequestTimeout = cfg.Timeout / 3
 	}
 
 	if cfg.MaxIdleConns == 0 {
@@ -211,6 +406,10 @@ func (c *Config) WithDefaults() *Config {
 		cfg.IdleConnTimeout = 90 * time.Second
 	}
 
+	if cfg.MaxResponseSize == 0 {
+		cfg.MaxResponseSize = DefaultMaxResponseSize
+	}
+
 	cfg.Retry = cfg.Retry.WithDefaults()
 
 	return &cfg
@@ -240,5 +439,9 @@ func (c RetryConfig) WithDefaults() RetryConfig {
 		cfg.Jitter = 0.1
 	}
 
+	if cfg.Policy == nil {
+		cfg.Policy = DefaultRetryPolicy()
+	}
+
 	return cfg
 }
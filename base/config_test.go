@@ -23,6 +23,9 @@ func TestDefaultConfig(t *testing.T) {
 	if cfg.IdleConnTimeout != 90*time.Second {
 		t.Errorf("expected IdleConnTimeout 90s, got %v", cfg.IdleConnTimeout)
 	}
+	if cfg.MaxResponseSize != 10<<20 {
+		t.Errorf("expected MaxResponseSize 10 MiB, got %d", cfg.MaxResponseSize)
+	}
 }
 
 func TestDefaultRetryConfig(t *testing.T) {
@@ -133,6 +136,18 @@ func TestConfigValidate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "request timeout cannot exceed total timeout",
 		},
+		{
+			name: "negative max response size",
+			config: &Config{
+				BaseURL:         "https://api.example.com",
+				Timeout:         30 * time.Second,
+				RequestTimeout:  10 * time.Second,
+				MaxResponseSize: -1,
+				Retry:           DefaultRetryConfig(),
+			},
+			wantErr: true,
+			errMsg:  "max response size cannot be negative",
+		},
 		{
 			name: "invalid retry config",
 			config: &Config{
@@ -160,6 +175,91 @@ func TestConfigValidate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "circuit breaker config",
 		},
+		{
+			name: "invalid hedging config",
+			config: &Config{
+				BaseURL:        "https://api.example.com",
+				Timeout:        30 * time.Second,
+				RequestTimeout: 10 * time.Second,
+				Retry:          DefaultRetryConfig(),
+				Hedging:        &HedgingConfig{},
+			},
+			wantErr: true,
+			errMsg:  "hedging config",
+		},
+		{
+			name: "invalid bulkhead config",
+			config: &Config{
+				BaseURL:        "https://api.example.com",
+				Timeout:        30 * time.Second,
+				RequestTimeout: 10 * time.Second,
+				Retry:          DefaultRetryConfig(),
+				Bulkhead:       &BulkheadConfig{MaxConcurrent: -1},
+			},
+			wantErr: true,
+			errMsg:  "bulkhead config",
+		},
+		{
+			name: "invalid rate limit config",
+			config: &Config{
+				BaseURL:        "https://api.example.com",
+				Timeout:        30 * time.Second,
+				RequestTimeout: 10 * time.Second,
+				Retry:          DefaultRetryConfig(),
+				RateLimit:      &RateLimitConfig{},
+			},
+			wantErr: true,
+			errMsg:  "rate limit config",
+		},
+		{
+			name: "invalid load balancer config",
+			config: &Config{
+				BaseURL:        "https://api.example.com",
+				Timeout:        30 * time.Second,
+				RequestTimeout: 10 * time.Second,
+				Retry:          DefaultRetryConfig(),
+				LoadBalancer:   &LoadBalancerConfig{},
+			},
+			wantErr: true,
+			errMsg:  "load balancer config",
+		},
+		{
+			name: "invalid cache config",
+			config: &Config{
+				BaseURL:        "https://api.example.com",
+				Timeout:        30 * time.Second,
+				RequestTimeout: 10 * time.Second,
+				Retry:          DefaultRetryConfig(),
+				Cache:          &CacheConfig{MaxEntries: -1},
+			},
+			wantErr: true,
+			errMsg:  "cache config",
+		},
+		{
+			name: "invalid failover config",
+			config: &Config{
+				BaseURL:        "https://api.example.com",
+				Timeout:        30 * time.Second,
+				RequestTimeout: 10 * time.Second,
+				Retry:          DefaultRetryConfig(),
+				Failover:       &FailoverConfig{},
+			},
+			wantErr: true,
+			errMsg:  "failover config",
+		},
+		{
+			name: "failover with load balancer",
+			config: &Config{
+				BaseURL:        "https://api.example.com",
+				Timeout:        30 * time.Second,
+				RequestTimeout: 10 * time.Second,
+				Retry:          DefaultRetryConfig(),
+				LoadBalancer:   &LoadBalancerConfig{Endpoints: []string{"http://10.0.0.1:8080"}},
+				Failover:       &FailoverConfig{SecondaryURL: "https://dr.example.com"},
+			},
+			wantErr: true,
+			errMsg:  "failover cannot be combined with a load balancer",
+		},
 	}
 
 	for _, tt := range tests {
@@ -348,6 +448,103 @@ func TestCircuitBreakerConfigValidate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "timeout must be positive",
 		},
+		{
+			name: "negative slow call duration",
+			config: &CircuitBreakerConfig{
+				FailureThreshold: 5,
+				SuccessThreshold: 2,
+				Timeout:          30 * time.Second,
+				SlowCallDuration: -time.Second,
+			},
+			wantErr: true,
+			errMsg:  "slow call duration cannot be negative",
+		},
+		{
+			name: "valid window mode without failure threshold",
+			config: &CircuitBreakerConfig{
+				Mode:                 CircuitModeCountWindow,
+				SuccessThreshold:     2,
+				Timeout:              30 * time.Second,
+				FailureRateThreshold: 0.4,
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown mode",
+			config: &CircuitBreakerConfig{
+				Mode:             CircuitBreakerMode(99),
+				SuccessThreshold: 2,
+				Timeout:          30 * time.Second,
+			},
+			wantErr: true,
+			errMsg:  "unknown mode",
+		},
+		{
+			name: "negative window size",
+			config: &CircuitBreakerConfig{
+				Mode:             CircuitModeCountWindow,
+				SuccessThreshold: 2,
+				Timeout:          30 * time.Second,
+				WindowSize:       -1,
+			},
+			wantErr: true,
+			errMsg:  "window size cannot be negative",
+		},
+		{
+			name: "negative window duration",
+			config: &CircuitBreakerConfig{
+				Mode:             CircuitModeTimeWindow,
+				SuccessThreshold: 2,
+				Timeout:          30 * time.Second,
+				WindowDuration:   -time.Second,
+			},
+			wantErr: true,
+			errMsg:  "window duration cannot be negative",
+		},
+		{
+			name: "negative minimum requests",
+			config: &CircuitBreakerConfig{
+				Mode:             CircuitModeTimeWindow,
+				SuccessThreshold: 2,
+				Timeout:          30 * time.Second,
+				MinimumRequests:  -1,
+			},
+			wantErr: true,
+			errMsg:  "minimum requests cannot be negative",
+		},
+		{
+			name: "failure rate above 1",
+			config: &CircuitBreakerConfig{
+				Mode:                 CircuitModeCountWindow,
+				SuccessThreshold:     2,
+				Timeout:              30 * time.Second,
+				FailureRateThreshold: 1.5,
+			},
+			wantErr: true,
+			errMsg:  "failure rate threshold must be between 0 and 1",
+		},
+		{
+			name: "slow call rate above 1",
+			config: &CircuitBreakerConfig{
+				Mode:                  CircuitModeCountWindow,
+				SuccessThreshold:      2,
+				Timeout:               30 * time.Second,
+				SlowCallRateThreshold: 1.5,
+			},
+			wantErr: true,
+			errMsg:  "slow call rate threshold must be between 0 and 1",
+		},
+		{
+			name: "slow call rate without duration",
+			config: &CircuitBreakerConfig{
+				Mode:                  CircuitModeCountWindow,
+				SuccessThreshold:      2,
+				Timeout:               30 * time.Second,
+				SlowCallRateThreshold: 0.5,
+			},
+			wantErr: true,
+			errMsg:  "slow call duration must be positive",
+		},
 	}
 
 	for _, tt := range tests {
@@ -390,11 +587,25 @@ func TestConfigWithDefaults(t *testing.T) {
 	if withDefaults.IdleConnTimeout != 90*time.Second {
 		t.Errorf("expected IdleConnTimeout 90s, got %v", withDefaults.IdleConnTimeout)
 	}
+	if withDefaults.MaxResponseSize != DefaultMaxResponseSize {
+		t.Errorf("expected MaxResponseSize %d, got %d", DefaultMaxResponseSize, withDefaults.MaxResponseSize)
+	}
 	if withDefaults.Retry.MaxRetries != 3 {
 		t.Errorf("expected Retry.MaxRetries 3, got %d", withDefaults.Retry.MaxRetries)
 	}
 }
 
+func TestConfigWithDefaultsDerivesRequestTimeout(t *testing.T) {
+	cfg := &Config{
+		BaseURL: "https://api.example.com",
+		Timeout: 15 * time.Second,
+	}
+
+	if got := cfg.WithDefaults().RequestTimeout; got != 5*time.Second {
+		t.Errorf("expected RequestTimeout 5s, got %v", got)
+	}
+}
+
 func TestRetryConfigWithDefaults(t *testing.T) {
 	cfg := RetryConfig{}
 	withDefaults := cfg.WithDefaults()
@@ -0,0 +1,73 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// HeaderRequestTimeout is the header carrying the time left for a request, in milliseconds.
+// The client sets it on every attempt; servers read it with ContextWithRequestTimeout
+// or DeadlineHandler to give up on work the caller will no longer wait for.
+//
+// A relative timeout is used rather than an absolute deadline so that clock skew
+// between hosts does not matter.
+const HeaderRequestTimeout = "X-Request-Timeout"
+
+// setTimeoutHeader sets the X-Request-Timeout header to the time left for the attempt,
+// which is bounded by both the call deadline and the per-attempt timeout.
+func (c *Client) setTimeoutHeader(req *http.Request) {
+	remaining := c.httpClient.Timeout
+	if deadline, ok := req.Context().Deadline(); ok {
+		if left := time.Until(deadline); remaining <= 0 || left < remaining {
+			remaining = left
+		}
+	}
+
+	if ms := remaining.Milliseconds(); ms > 0 {
+		req.Header.Set(HeaderRequestTimeout, strconv.FormatInt(ms, 10))
+	}
+}
+
+// RequestTimeout returns the time the caller has left for the request,
+// read from the X-Request-Timeout header. It returns false if the header
+// is missing or invalid.
+func RequestTimeout(r *http.Request) (time.Duration, bool) {
+	value := r.Header.Get(HeaderRequestTimeout)
+	if value == "" {
+		return 0, false
+	}
+
+	ms, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || ms <= 0 {
+		return 0, false
+	}
+
+	return time.Duration(ms) * time.Millisecond, true
+}
+
+// ContextWithRequestTimeout returns a copy of ctx that is cancelled when the time
+// the caller has left for the request runs out. The deadline of ctx is kept if it
+// is sooner. If the request has no X-Request-Timeout header, ctx is returned as is.
+//
+// Calls made with the returned context through a Client propagate the remaining
+// time to the next service.
+func ContextWithRequestTimeout(ctx context.Context, r *http.Request) (context.Context, context.CancelFunc) {
+	timeout, ok := RequestTimeout(r)
+	if !ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// DeadlineHandler wraps a server handler so that the request context is cancelled
+// when the time the caller has left for the request runs out.
+func DeadlineHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := ContextWithRequestTimeout(r.Context(), r)
+		defer cancel()
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,146 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"missing", "", 0, false},
+		{"valid", "1500", 1500 * time.Millisecond, true},
+		{"zero", "0", 0, false},
+		{"negative", "-10", 0, false},
+		{"invalid", "1s", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				r.Header.Set(HeaderRequestTimeout, tt.header)
+			}
+
+			got, ok := RequestTimeout(r)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("expected (%v, %v), got (%v, %v)", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+}
+
+func TestContextWithRequestTimeout(t *testing.T) {
+	t.Run("applies the caller timeout", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set(HeaderRequestTimeout, "500")
+
+		ctx, cancel := ContextWithRequestTimeout(context.Background(), r)
+		defer cancel()
+
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			t.Fatal("expected a deadline")
+		}
+		if left := time.Until(deadline); left <= 0 || left > 500*time.Millisecond {
+			t.Errorf("expected deadline within 500ms, got %v", left)
+		}
+	})
+
+	t.Run("keeps a sooner deadline", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set(HeaderRequestTimeout, "60000")
+
+		parent, cancelParent := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancelParent()
+
+		ctx, cancel := ContextWithRequestTimeout(parent, r)
+		defer cancel()
+
+		deadline, _ := ctx.Deadline()
+		if left := time.Until(deadline); left > 100*time.Millisecond {
+			t.Errorf("expected deadline within 100ms, got %v", left)
+		}
+	})
+
+	t.Run("without header", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+		ctx, cancel := ContextWithRequestTimeout(context.Background(), r)
+		defer cancel()
+
+		if _, ok := ctx.Deadline(); ok {
+			t.Error("expected no deadline")
+		}
+	})
+}
+
+func TestDeadlineHandler(t *testing.T) {
+	t.Run("propagates the deadline through services", func(t *testing.T) {
+		// downstream is called by upstream with the context of the incoming request.
+		var downstreamTimeout string
+		downstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			downstreamTimeout = r.Header.Get(HeaderRequestTimeout)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer downstream.Close()
+
+		downstreamClient, err := NewClient(&Config{BaseURL: downstream.URL}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		upstream := httptest.NewServer(DeadlineHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, err := downstreamClient.Get(r.Context(), "/test").Do(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			w.WriteHeader(http.StatusOK)
+		})))
+		defer upstream.Close()
+
+		upstreamClient, err := NewClient(&Config{BaseURL: upstream.URL}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		if _, err := upstreamClient.Get(context.Background(), "/test").WithTimeout(2 * time.Second).Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		ms, err := strconv.Atoi(downstreamTimeout)
+		if err != nil {
+			t.Fatalf("expected %s header, got %q", HeaderRequestTimeout, downstreamTimeout)
+		}
+		if ms <= 0 || ms > 2000 {
+			t.Errorf("expected remaining time of at most 2000ms, got %d", ms)
+		}
+	})
+
+	t.Run("cancels work after the caller timeout", func(t *testing.T) {
+		handler := DeadlineHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+				w.WriteHeader(http.StatusGatewayTimeout)
+			case <-time.After(time.Second):
+				w.WriteHeader(http.StatusOK)
+			}
+		}))
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set(HeaderRequestTimeout, "20")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		if w.Code != http.StatusGatewayTimeout {
+			t.Errorf("expected status 504, got %d", w.Code)
+		}
+	})
+}
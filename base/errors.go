@@ -18,13 +18,28 @@ const (
 	CodeCircuitOpen errors.Code = "CIRCUIT_OPEN"
 	// CodeBadGateway indicates the upstream service returned an invalid response.
 	CodeBadGateway errors.Code = "BAD_GATEWAY"
+	// CodeRetryBudgetExhausted indicates a retry was skipped because the retry budget is exhausted.
+	CodeRetryBudgetExhausted errors.Code = "RETRY_BUDGET_EXHAUSTED"
+	// CodeBulkheadFull indicates a call was rejected because the concurrency limit is reached.
+	CodeBulkheadFull errors.Code = "BULKHEAD_FULL"
+	// CodeRateLimitExceeded indicates a call was rejected by the client-side rate limiter.
+	CodeRateLimitExceeded errors.Code = "RATE_LIMIT_EXCEEDED"
+	// CodeNoHealthyEndpoint indicates every load balancer endpoint is ejected or has an open circuit.
+	CodeNoHealthyEndpoint errors.Code = "NO_HEALTHY_ENDPOINT"
+	// CodeResponseTooLarge indicates the upstream response body exceeded the maximum response size.
+	CodeResponseTooLarge errors.Code = "RESPONSE_TOO_LARGE"
 )
 
 // codeHTTPStatus maps client-specific error codes to HTTP status codes.
 var codeHTTPStatus = map[errors.Code]int{
-	CodeTimeout:     http.StatusGatewayTimeout,
-	CodeCircuitOpen: http.StatusServiceUnavailable,
-	CodeBadGateway:  http.StatusBadGateway,
+	CodeTimeout:              http.StatusGatewayTimeout,
+	CodeCircuitOpen:          http.StatusServiceUnavailable,
+	CodeBadGateway:           http.StatusBadGateway,
+	CodeRetryBudgetExhausted: http.StatusServiceUnavailable,
+	CodeBulkheadFull:         http.StatusServiceUnavailable,
+	CodeRateLimitExceeded:    http.StatusTooManyRequests,
+	CodeNoHealthyEndpoint:    http.StatusServiceUnavailable,
+	CodeResponseTooLarge:     http.StatusBadGateway,
 }
 
 // HTTPStatusForCode returns the HTTP status code for a client-specific error code.
@@ -68,6 +83,31 @@ func ErrBadGatewayWrap(message string, cause error) *errors.AppError {
 	return errors.Wrap(CodeBadGateway, message, cause)
 }
 
+// ErrRetryBudgetExhausted creates a retry budget exhausted error wrapping the error of the last attempt.
+func ErrRetryBudgetExhausted(service string, cause error) *errors.AppError {
+	return errors.Wrap(CodeRetryBudgetExhausted, fmt.Sprintf("retry budget exhausted for %s", service), cause)
+}
+
+// ErrBulkheadFull creates a bulkhead full error.
+func ErrBulkheadFull(service string) *errors.AppError {
+	return errors.New(CodeBulkheadFull, fmt.Sprintf("too many concurrent calls to %s", service))
+}
+
+// ErrRateLimitExceeded creates a client-side rate limit exceeded error.
+func ErrRateLimitExceeded(service string) *errors.AppError {
+	return errors.New(CodeRateLimitExceeded, fmt.Sprintf("client rate limit exceeded for %s", service))
+}
+
+// ErrNoHealthyEndpoint creates a no healthy endpoint error.
+func ErrNoHealthyEndpoint(service string) *errors.AppError {
+	return errors.New(CodeNoHealthyEndpoint, fmt.Sprintf("no healthy endpoint for %s", service))
+}
+
+// ErrResponseTooLarge creates a response too large error.
+func ErrResponseTooLarge(limit int64) *errors.AppError {
+	return errors.New(CodeResponseTooLarge, fmt.Sprintf("response body exceeds %d bytes", limit))
+}
+
 // IsTimeout checks if the error is a timeout error.
 func IsTimeout(err error) bool {
 	return errors.IsCode(err, CodeTimeout)
@@ -83,6 +123,31 @@ func IsBadGateway(err error) bool {
 	return errors.IsCode(err, CodeBadGateway)
 }
 
+// IsRetryBudgetExhausted checks if the error is a retry budget exhausted error.
+func IsRetryBudgetExhausted(err error) bool {
+	return errors.IsCode(err, CodeRetryBudgetExhausted)
+}
+
+// IsBulkheadFull checks if the error is a bulkhead full error.
+func IsBulkheadFull(err error) bool {
+	return errors.IsCode(err, CodeBulkheadFull)
+}
+
+// IsRateLimitExceeded checks if the error is a client-side rate limit exceeded error.
+func IsRateLimitExceeded(err error) bool {
+	return errors.IsCode(err, CodeRateLimitExceeded)
+}
+
+// IsNoHealthyEndpoint checks if the error is a no healthy endpoint error.
+func IsNoHealthyEndpoint(err error) bool {
+	return errors.IsCode(err, CodeNoHealthyEndpoint)
+}
+
+// IsResponseTooLarge checks if the error is a response too large error.
+func IsResponseTooLarge(err error) bool {
+	return errors.IsCode(err, CodeResponseTooLarge)
+}
+
 // IsRetryable returns true if the error is retryable.
 // Retryable errors include: timeouts, rate limits, service unavailable, and server errors.
 func IsRetryable(err error) bool {
@@ -28,6 +28,31 @@ func TestHTTPStatusForCode(t *testing.T) {
 			code:     CodeBadGateway,
 			expected: http.StatusBadGateway,
 		},
+		{
+			name:     "retry budget exhausted code",
+			code:     CodeRetryBudgetExhausted,
+			expected: http.StatusServiceUnavailable,
+		},
+		{
+			name:     "bulkhead full code",
+			code:     CodeBulkheadFull,
+			expected: http.StatusServiceUnavailable,
+		},
+		{
+			name:     "rate limit exceeded code",
+			code:     CodeRateLimitExceeded,
+			expected: http.StatusTooManyRequests,
+		},
+		{
+			name:     "no healthy endpoint code",
+			code:     CodeNoHealthyEndpoint,
+			expected: http.StatusServiceUnavailable,
+		},
+		{
+			name:     "response too large code",
+			code:     CodeResponseTooLarge,
+			expected: http.StatusBadGateway,
+		},
 		{
 			name:     "core validation error code",
 			code:     errors.CodeValidationError,
@@ -111,6 +136,65 @@ func TestErrorConstructors(t *testing.T) {
 			t.Error("expected wrapped error, got nil")
 		}
 	})
+
+	t.Run("ErrRetryBudgetExhausted", func(t *testing.T) {
+		cause := errors.New(errors.CodeServiceUnavailable, "service unavailable")
+		err := ErrRetryBudgetExhausted("ride-service", cause)
+		if err.Code() != CodeRetryBudgetExhausted {
+			t.Errorf("expected code %s, got %s", CodeRetryBudgetExhausted, err.Code())
+		}
+		expected := "retry budget exhausted for ride-service"
+		if err.Message() != expected {
+			t.Errorf("expected message '%s', got %s", expected, err.Message())
+		}
+		if err.Unwrap() == nil {
+			t.Error("expected wrapped error, got nil")
+		}
+	})
+
+	t.Run("ErrBulkheadFull", func(t *testing.T) {
+		err := ErrBulkheadFull("safety-service")
+		if err.Code() != CodeBulkheadFull {
+			t.Errorf("expected code %s, got %s", CodeBulkheadFull, err.Code())
+		}
+		expected := "too many concurrent calls to safety-service"
+		if err.Message() != expected {
+			t.Errorf("expected message '%s', got %s", expected, err.Message())
+		}
+	})
+
+	t.Run("ErrRateLimitExceeded", func(t *testing.T) {
+		err := ErrRateLimitExceeded("sms")
+		if err.Code() != CodeRateLimitExceeded {
+			t.Errorf("expected code %s, got %s", CodeRateLimitExceeded, err.Code())
+		}
+		expected := "client rate limit exceeded for sms"
+		if err.Message() != expected {
+			t.Errorf("expected message '%s', got %s", expected, err.Message())
+		}
+	})
+
+	t.Run("ErrNoHealthyEndpoint", func(t *testing.T) {
+		err := ErrNoHealthyEndpoint("ride-service")
+		if err.Code() != CodeNoHealthyEndpoint {
+			t.Errorf("expected code %s, got %s", CodeNoHealthyEndpoint, err.Code())
+		}
+		expected := "no healthy endpoint for ride-service"
+		if err.Message() != expected {
+			t.Errorf("expected message '%s', got %s", expected, err.Message())
+		}
+	})
+
+	t.Run("ErrResponseTooLarge", func(t *testing.T) {
+		err := ErrResponseTooLarge(1024)
+		if err.Code() != CodeResponseTooLarge {
+			t.Errorf("expected code %s, got %s", CodeResponseTooLarge, err.Code())
+		}
+		expected := "response body exceeds 1024 bytes"
+		if err.Message() != expected {
+			t.Errorf("expected message '%s', got %s", expected, err.Message())
+		}
+	})
 }
 
 func TestErrorCheckers(t *testing.T) {
@@ -149,6 +233,58 @@ func TestErrorCheckers(t *testing.T) {
 			t.Error("expected IsBadGateway to return false for non-bad-gateway error")
 		}
 	})
+
+	t.Run("IsRetryBudgetExhausted", func(t *testing.T) {
+		budgetErr := ErrRetryBudgetExhausted("service", nil)
+		if !IsRetryBudgetExhausted(budgetErr) {
+			t.Error("expected IsRetryBudgetExhausted to return true for retry budget error")
+		}
+
+		otherErr := ErrTimeout("timeout")
+		if IsRetryBudgetExhausted(otherErr) {
+			t.Error("expected IsRetryBudgetExhausted to return false for non-budget error")
+		}
+	})
+
+	t.Run("IsBulkheadFull", func(t *testing.T) {
+		if !IsBulkheadFull(ErrBulkheadFull("service")) {
+			t.Error("expected IsBulkheadFull to return true for bulkhead error")
+		}
+
+		if IsBulkheadFull(ErrTimeout("timeout")) {
+			t.Error("expected IsBulkheadFull to return false for non-bulkhead error")
+		}
+	})
+
+	t.Run("IsRateLimitExceeded", func(t *testing.T) {
+		if !IsRateLimitExceeded(ErrRateLimitExceeded("service")) {
+			t.Error("expected IsRateLimitExceeded to return true for rate limit error")
+		}
+
+		if IsRateLimitExceeded(ErrTimeout("timeout")) {
+			t.Error("expected IsRateLimitExceeded to return false for non-rate limit error")
+		}
+	})
+
+	t.Run("IsNoHealthyEndpoint", func(t *testing.T) {
+		if !IsNoHealthyEndpoint(ErrNoHealthyEndpoint("service")) {
+			t.Error("expected IsNoHealthyEndpoint to return true for no healthy endpoint error")
+		}
+
+		if IsNoHealthyEndpoint(ErrTimeout("timeout")) {
+			t.Error("expected IsNoHealthyEndpoint to return false for other errors")
+		}
+	})
+
+	t.Run("IsResponseTooLarge", func(t *testing.T) {
+		if !IsResponseTooLarge(ErrResponseTooLarge(1024)) {
+			t.Error("expected IsResponseTooLarge to return true for response too large error")
+		}
+
+		if IsResponseTooLarge(ErrTimeout("timeout")) {
+			t.Error("expected IsResponseTooLarge to return false for other errors")
+		}
+	})
 }
 
 func TestIsRetryable(t *testing.T) {
@@ -0,0 +1,344 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Failover defaults.
+const (
+	// Default path probed on the primary endpoint.
+	defaultFailoverHealthPath = "/health"
+
+	// Default interval between health probes while failed over.
+	defaultFailoverProbeInterval = 10 * time.Second
+
+	// Default time limit for a health probe.
+	defaultFailoverProbeTimeout = 5 * time.Second
+
+	// Default number of successful probes in a row that fails back to the primary.
+	defaultFailoverHealthyProbes = 3
+)
+
+// FailoverConfig holds primary/secondary failover configuration.
+// The client BaseURL is the primary endpoint. Calls fail over to the secondary when the
+// primary circuit breaker is open or an idempotent call exhausts its retries against the
+// primary. The client then sends every call to the secondary and probes the primary's
+// health endpoint until it is healthy again, and fails back.
+type FailoverConfig struct {
+	// SecondaryURL is the base URL of the secondary deployment (required).
+	SecondaryURL string
+
+	// HealthPath is the path probed on the primary while failed over (default: "/health").
+	// A probe succeeds if it returns a 2xx status.
+	HealthPath string
+
+	// ProbeInterval is the time between health probes (default: 10s).
+	// Probes are sent in the background while calls are being made.
+	ProbeInterval time.Duration
+
+	// ProbeTimeout is the time limit for a health probe (default: 5s).
+	ProbeTimeout time.Duration
+
+	// HealthyProbes is the number of successful probes in a row that fails back
+	// to the primary (default: 3).
+	HealthyProbes int
+}
+
+// Validate validates the failover configuration.
+func (c *FailoverConfig) Validate() error {
+	if c.SecondaryURL == "" {
+		return fmt.Errorf("secondary URL is required")
+	}
+	if _, err := parseEndpoint(c.SecondaryURL); err != nil {
+		return err
+	}
+
+	if c.HealthPath != "" && !strings.HasPrefix(c.HealthPath, "/") {
+		return fmt.Errorf("health path must start with /")
+	}
+
+	if c.ProbeInterval < 0 {
+		return fmt.Errorf("probe interval cannot be negative")
+	}
+
+	if c.ProbeTimeout < 0 {
+		return fmt.Errorf("probe timeout cannot be negative")
+	}
+
+	if c.HealthyProbes < 0 {
+		return fmt.Errorf("healthy probes cannot be negative")
+	}
+
+	return nil
+}
+
+// failoverTarget is the primary or secondary endpoint.
+type failoverTarget struct {
+	url     *url.URL
+	breaker *CircuitBreaker
+}
+
+// failover tracks which endpoint is active and probes the primary while failed over.
+type failover struct {
+	primary       *failoverTarget
+	secondary     *failoverTarget
+	healthURL     string
+	probeInterval time.Duration
+	probeTimeout  time.Duration
+	healthyProbes int
+
+	mu        sync.Mutex
+	active    *failoverTarget
+	lastProbe time.Time
+	probing   bool
+	successes int
+}
+
+// newFailover creates the failover state for a client. The secondary endpoint gets its
+// own circuit breaker, configured like the primary's, named after its host. Its state is
+// recorded under the service label with a "/secondary" suffix.
+func newFailover(cfg *FailoverConfig, primary *url.URL, primaryBreaker *CircuitBreaker, breakerConfig *CircuitBreakerConfig, metrics Metrics, service string) *failover {
+	secondaryURL, _ := parseEndpoint(cfg.SecondaryURL)
+
+	var secondaryBreaker *CircuitBreaker
+	if breakerConfig != nil {
+		label := service + "/secondary"
+		cbConfig := *breakerConfig
+		cbConfig.Name = secondaryURL.Host
+		cbConfig.OnStateChange = circuitStateRecorder(metrics, label, cbConfig.OnStateChange)
+		secondaryBreaker = NewCircuitBreaker(&cbConfig)
+		metrics.SetCircuitState(label, secondaryBreaker.State())
+	}
+
+	healthPath := cfg.HealthPath
+	if healthPath == "" {
+		healthPath = defaultFailoverHealthPath
+	}
+
+	probeInterval := cfg.ProbeInterval
+	if probeInterval == 0 {
+		probeInterval = defaultFailoverProbeInterval
+	}
+
+	probeTimeout := cfg.ProbeTimeout
+	if probeTimeout == 0 {
+		probeTimeout = defaultFailoverProbeTimeout
+	}
+
+	healthyProbes := cfg.HealthyProbes
+	if healthyProbes == 0 {
+		healthyProbes = defaultFailoverHealthyProbes
+	}
+
+	f := &failover{
+		primary:       &failoverTarget{url: primary, breaker: primaryBreaker},
+		secondary:     &failoverTarget{url: secondaryURL, breaker: secondaryBreaker},
+		healthURL:     primary.String() + healthPath,
+		probeInterval: probeInterval,
+		probeTimeout:  probeTimeout,
+		healthyProbes: healthyProbes,
+	}
+	f.active = f.primary
+	return f
+}
+
+// current returns the active endpoint.
+func (f *failover) current() *failoverTarget {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.active
+}
+
+// failOver makes the secondary endpoint active and reports whether it was not already.
+func (f *failover) failOver() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.active == f.secondary {
+		return false
+	}
+	f.active = f.secondary
+	f.lastProbe = time.Now()
+	f.successes = 0
+	return true
+}
+
+// maybeProbe starts a background health probe of the primary if one is due.
+func (f *failover) maybeProbe(c *Client) {
+	f.mu.Lock()
+	if f.active != f.secondary || f.probing || time.Since(f.lastProbe) < f.probeInterval {
+		f.mu.Unlock()
+		return
+	}
+	f.probing = true
+	f.mu.Unlock()
+
+	go func() {
+		healthy := f.probe(c.httpClient)
+
+		f.mu.Lock()
+		f.probing = false
+		f.lastProbe = time.Now()
+		if !healthy {
+			f.successes = 0
+			f.mu.Unlock()
+			return
+		}
+		f.successes++
+		if f.successes < f.healthyProbes || f.active != f.secondary {
+			f.mu.Unlock()
+			return
+		}
+		f.successes = 0
+		f.mu.Unlock()
+
+		// The primary circuit is closed before calls are sent to it again.
+		// It is reset without f.mu held, since its hooks may read the stats.
+		if f.primary.breaker != nil {
+			f.primary.breaker.Reset()
+		}
+
+		f.mu.Lock()
+		f.active = f.primary
+		f.mu.Unlock()
+
+		c.logFailback(f.primary.url.String(), f.secondary.url.String())
+	}()
+}
+
+// probe sends a health probe to the primary and reports whether it returned a 2xx status.
+func (f *failover) probe(httpClient *http.Client) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), f.probeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.healthURL, nil)
+	if err != nil {
+		return false
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode >= 200 && resp.StatusCode < 300
+}
+
+// shouldFailOver reports whether a call that went to the primary should be sent to the secondary.
+// Calls rejected by the open circuit were never sent; other failures are only sent again if
+// the call is idempotent and its body can be replayed.
+func shouldFailOver(ctx context.Context, req *http.Request, info *CallInfo, resp *Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		if IsCircuitOpen(err) {
+			return true
+		}
+		if IsRateLimitExceeded(err) {
+			return false
+		}
+	} else if resp.StatusCode < 500 {
+		return false
+	}
+
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	return info.Idempotent && replayable
+}
+
+// FailoverMiddleware sends every call to the active endpoint of a client with failover
+// configured, and fails over to the secondary endpoint when the primary circuit breaker is
+// open or an idempotent call exhausts its retries against the primary.
+// It must run before CircuitBreakerMiddleware, which then uses the circuit breaker of the
+// endpoint the call is sent to. It has no effect if the client has no failover configured.
+func FailoverMiddleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *http.Request) (*Response, error) {
+			info := CallInfoFromContext(ctx)
+			if info == nil || info.client.failover == nil {
+				return next(ctx, req)
+			}
+
+			f := info.client.failover
+			if f.current() == f.secondary {
+				f.maybeProbe(info.client)
+				return sendToSecondary(ctx, req, info, f, next)
+			}
+
+			info.breaker = f.primary.breaker
+			info.endpoint = f.primary.url.String()
+			resp, err := next(ctx, req)
+			if !shouldFailOver(ctx, req, info, resp, err) {
+				return resp, err
+			}
+
+			reason := err
+			if reason == nil {
+				reason = fmt.Errorf("status %d", resp.StatusCode)
+			}
+			if f.failOver() {
+				info.client.logFailover(ctx, f.primary.url.String(), f.secondary.url.String(), reason)
+			}
+			return sendToSecondary(ctx, req, info, f, next)
+		}
+	}
+}
+
+// sendToSecondary sends the call to the secondary endpoint.
+func sendToSecondary(ctx context.Context, req *http.Request, info *CallInfo, f *failover, next Handler) (*Response, error) {
+	secondaryReq := req.Clone(ctx)
+	secondaryReq.URL = endpointURL(req.URL, f.primary.url, f.secondary.url)
+	secondaryReq.Host = ""
+
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to recreate request body: %w", err)
+		}
+		secondaryReq.Body = body
+	}
+
+	info.breaker = f.secondary.breaker
+	info.endpoint = f.secondary.url.String()
+	info.attempts = 0
+	info.attemptDuration = 0
+	return next(ctx, secondaryReq)
+}
+
+// logFailover logs a failover to the secondary endpoint.
+func (c *Client) logFailover(ctx context.Context, primary, secondary string, reason error) {
+	if c.logger == nil {
+		return
+	}
+
+	c.logger.WarnContext(ctx, "http client failed over to secondary endpoint",
+		"service", c.serviceName,
+		"primary", primary,
+		"secondary", secondary,
+		"error", reason.Error(),
+	)
+}
+
+// logFailback logs a failback to the primary endpoint.
+func (c *Client) logFailback(primary, secondary string) {
+	if c.logger == nil {
+		return
+	}
+
+	c.logger.InfoContext(context.Background(), "http client failed back to primary endpoint",
+		"service", c.serviceName,
+		"primary", primary,
+		"secondary", secondary,
+	)
+}
@@ -0,0 +1,230 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFailoverConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config FailoverConfig
+		errMsg string
+	}{
+		{"valid", FailoverConfig{SecondaryURL: "https://payment-dr.example.com", HealthPath: "/ready"}, ""},
+		{"missing secondary URL", FailoverConfig{}, "secondary URL is required"},
+		{"secondary URL without scheme", FailoverConfig{SecondaryURL: "payment-dr:8080"}, "scheme and host are required"},
+		{"relative health path", FailoverConfig{SecondaryURL: "http://a", HealthPath: "health"}, "health path must start with /"},
+		{"negative probe interval", FailoverConfig{SecondaryURL: "http://a", ProbeInterval: -time.Second}, "probe interval cannot be negative"},
+		{"negative probe timeout", FailoverConfig{SecondaryURL: "http://a", ProbeTimeout: -time.Second}, "probe timeout cannot be negative"},
+		{"negative healthy probes", FailoverConfig{SecondaryURL: "http://a", HealthyProbes: -1}, "healthy probes cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing '%s', got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestClientFailover(t *testing.T) {
+	newServer := func(status *atomic.Int32, hits *atomic.Int32) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/health" {
+				hits.Add(1)
+			}
+			w.WriteHeader(int(status.Load()))
+		}))
+	}
+
+	newClient := func(t *testing.T, primary, secondary string) *Client {
+		t.Helper()
+		client, err := NewClient(&Config{
+			BaseURL:        primary,
+			Retry:          RetryConfig{MaxRetries: 1, InitialWait: time.Millisecond, MaxWait: time.Millisecond, Multiplier: 1.0},
+			CircuitBreaker: &CircuitBreakerConfig{FailureThreshold: 1, SuccessThreshold: 1, Timeout: time.Minute},
+			Failover: &FailoverConfig{
+				SecondaryURL:  secondary,
+				ProbeInterval: time.Millisecond,
+				HealthyProbes: 2,
+			},
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		return client
+	}
+
+	t.Run("fails over when retries are exhausted and fails back", func(t *testing.T) {
+		var primaryStatus, secondaryStatus, primaryHits, secondaryHits atomic.Int32
+		primaryStatus.Store(http.StatusServiceUnavailable)
+		secondaryStatus.Store(http.StatusOK)
+		primary := newServer(&primaryStatus, &primaryHits)
+		defer primary.Close()
+		secondary := newServer(&secondaryStatus, &secondaryHits)
+		defer secondary.Close()
+
+		client := newClient(t, primary.URL, secondary.URL)
+
+		resp, err := client.Get(context.Background(), "/payments/1").Do()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected status 200, got %d", resp.StatusCode)
+		}
+		if got := primaryHits.Load(); got != 2 {
+			t.Errorf("expected 2 calls to the primary, got %d", got)
+		}
+		if stats := client.CircuitBreakerStats(); stats.ActiveEndpoint != secondary.URL {
+			t.Errorf("expected active endpoint %s, got %s", secondary.URL, stats.ActiveEndpoint)
+		}
+
+		// While failed over, calls skip the primary until it passes its health probes.
+		primaryStatus.Store(http.StatusOK)
+		deadline := time.Now().Add(5 * time.Second)
+		for client.CircuitBreakerStats().ActiveEndpoint != primary.URL {
+			if time.Now().After(deadline) {
+				t.Fatal("client did not fail back to the primary")
+			}
+			if _, err := client.Get(context.Background(), "/payments/1").Do(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		if got := primaryHits.Load(); got != 2 {
+			t.Errorf("expected no calls to the primary while failed over, got %d", got-2)
+		}
+
+		if _, err := client.Get(context.Background(), "/payments/1").Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := primaryHits.Load(); got != 3 {
+			t.Errorf("expected the call to go to the primary after failback, got %d calls", got)
+		}
+		if stats := client.CircuitBreakerStats(); stats.State != CircuitClosed {
+			t.Errorf("expected primary circuit to be closed, got %s", stats.State)
+		}
+	})
+
+	t.Run("fails over when the primary circuit is open", func(t *testing.T) {
+		var primaryStatus, secondaryStatus, primaryHits, secondaryHits atomic.Int32
+		primaryStatus.Store(http.StatusOK)
+		secondaryStatus.Store(http.StatusOK)
+		primary := newServer(&primaryStatus, &primaryHits)
+		defer primary.Close()
+		secondary := newServer(&secondaryStatus, &secondaryHits)
+		defer secondary.Close()
+
+		client := newClient(t, primary.URL, secondary.URL)
+		client.circuitBreaker.RecordFailure()
+
+		resp, err := client.Post(context.Background(), "/payments", map[string]string{"ride_id": "r1"}).Do()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected status 200, got %d", resp.StatusCode)
+		}
+		if primaryHits.Load() != 0 || secondaryHits.Load() != 1 {
+			t.Errorf("expected the call to go to the secondary only, got %d and %d", primaryHits.Load(), secondaryHits.Load())
+		}
+	})
+
+	t.Run("keeps escaped path parameters on the secondary", func(t *testing.T) {
+		var primaryStatus, primaryHits atomic.Int32
+		primaryStatus.Store(http.StatusOK)
+		primary := newServer(&primaryStatus, &primaryHits)
+		defer primary.Close()
+
+		paths := make(chan string, 1)
+		secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			paths <- r.URL.EscapedPath()
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer secondary.Close()
+
+		client := newClient(t, primary.URL, secondary.URL)
+		client.circuitBreaker.RecordFailure()
+
+		if _, err := client.Get(context.Background(), "/payments/{id}", Param("id", "a/b")).Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if path := <-paths; path != "/payments/a%2Fb" {
+			t.Errorf("expected escaped path /payments/a%%2Fb, got %s", path)
+		}
+	})
+
+	t.Run("does not fail over non-idempotent calls that were sent", func(t *testing.T) {
+		var primaryStatus, secondaryStatus, primaryHits, secondaryHits atomic.Int32
+		primaryStatus.Store(http.StatusInternalServerError)
+		secondaryStatus.Store(http.StatusOK)
+		primary := newServer(&primaryStatus, &primaryHits)
+		defer primary.Close()
+		secondary := newServer(&secondaryStatus, &secondaryHits)
+		defer secondary.Close()
+
+		client := newClient(t, primary.URL, secondary.URL)
+
+		resp, err := client.Post(context.Background(), "/payments", map[string]string{"ride_id": "r1"}).Do()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.StatusCode != http.StatusInternalServerError {
+			t.Errorf("expected status 500, got %d", resp.StatusCode)
+		}
+		if got := secondaryHits.Load(); got != 0 {
+			t.Errorf("expected no calls to the secondary, got %d", got)
+		}
+		if stats := client.CircuitBreakerStats(); stats.ActiveEndpoint != primary.URL {
+			t.Errorf("expected active endpoint %s, got %s", primary.URL, stats.ActiveEndpoint)
+		}
+	})
+	t.Run("reports the secondary circuit separately", func(t *testing.T) {
+		metrics := &recordingMetrics{}
+		client, err := NewClient(&Config{
+			BaseURL:        "http://payment-service:8080",
+			CircuitBreaker: &CircuitBreakerConfig{FailureThreshold: 1, SuccessThreshold: 1, Timeout: time.Minute},
+			Failover:       &FailoverConfig{SecondaryURL: "http://payment-dr:8080"},
+			Metrics:        metrics,
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		events, cancel := client.SubscribeCircuitBreaker(2)
+		defer cancel()
+
+		client.failover.secondary.breaker.RecordFailure()
+
+		if change := <-events; change.Name != "payment-dr:8080" || change.To != CircuitOpen {
+			t.Errorf("expected the secondary circuit to open, got %+v", change)
+		}
+
+		metrics.mu.Lock()
+		defer metrics.mu.Unlock()
+		label := client.serviceName + "/secondary"
+		if n := len(metrics.circuitLabels); n == 0 || metrics.circuitLabels[n-1] != label || metrics.circuitStates[n-1] != CircuitOpen {
+			t.Errorf("expected the open state under %q, got %v %v", label, metrics.circuitLabels, metrics.circuitStates)
+		}
+		for i, l := range metrics.circuitLabels {
+			if l == client.serviceName && metrics.circuitStates[i] != CircuitClosed {
+				t.Errorf("expected the primary circuit to stay closed, got %v", metrics.circuitStates[i])
+			}
+		}
+	})
+}
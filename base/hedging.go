@@ -0,0 +1,234 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Hedging defaults.
+const (
+	// Default maximum number of concurrent requests per attempt.
+	defaultHedgingMaxAttempts = 2
+
+	// Number of latencies kept to compute the hedging delay percentile.
+	hedgingLatencySamples = 100
+
+	// Minimum number of latencies observed before the percentile is used.
+	hedgingMinLatencySamples = 20
+)
+
+// HedgingConfig holds hedged request configuration.
+// Hedging sends extra copies of a slow request and uses the first successful response.
+// It only applies to idempotent requests that opt in with Request.WithHedging.
+type HedgingConfig struct {
+	// MaxAttempts is the maximum number of concurrent requests, including the original (default: 2).
+	MaxAttempts int
+
+	// Delay is how long to wait for a response before sending the next hedged request.
+	// If Percentile is set, Delay is only used until enough latencies have been observed;
+	// if Delay is also zero, no hedged requests are sent until then.
+	Delay time.Duration
+
+	// Percentile is the observed latency percentile, between 0 and 1, used as the delay (e.g. 0.95).
+	// If zero, the fixed Delay is used.
+	Percentile float64
+}
+
+// Validate validates the hedging configuration.
+func (c *HedgingConfig) Validate() error {
+	if c.MaxAttempts < 0 {
+		return fmt.Errorf("max attempts cannot be negative")
+	}
+
+	if c.Delay < 0 {
+		return fmt.Errorf("delay cannot be negative")
+	}
+
+	if c.Percentile < 0 || c.Percentile > 1.0 {
+		return fmt.Errorf("percentile must be between 0 and 1")
+	}
+
+	if c.Delay == 0 && c.Percentile == 0 {
+		return fmt.Errorf("delay or percentile is required")
+	}
+
+	return nil
+}
+
+// hedger computes hedging delays from the configuration and observed latencies.
+type hedger struct {
+	maxAttempts int
+	delay       time.Duration
+	percentile  float64
+
+	mu        sync.Mutex
+	latencies []time.Duration
+	next      int
+}
+
+// newHedger creates a hedger from the config, or returns nil if cfg is nil.
+func newHedger(cfg *HedgingConfig) *hedger {
+	if cfg == nil {
+		return nil
+	}
+
+	maxAttempts := cfg.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultHedgingMaxAttempts
+	}
+
+	return &hedger{
+		maxAttempts: maxAttempts,
+		delay:       cfg.Delay,
+		percentile:  cfg.Percentile,
+		latencies:   make([]time.Duration, 0, hedgingLatencySamples),
+	}
+}
+
+// observe records the latency of a successful request.
+func (h *hedger) observe(d time.Duration) {
+	if h.percentile == 0 {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.latencies) < hedgingLatencySamples {
+		h.latencies = append(h.latencies, d)
+		return
+	}
+	h.latencies[h.next] = d
+	h.next = (h.next + 1) % hedgingLatencySamples
+}
+
+// hedgeDelay returns the delay before the next hedged request,
+// or false if no hedged request should be sent.
+func (h *hedger) hedgeDelay() (time.Duration, bool) {
+	if h.percentile == 0 {
+		return h.delay, true
+	}
+
+	h.mu.Lock()
+	if len(h.latencies) < hedgingMinLatencySamples {
+		h.mu.Unlock()
+		return h.delay, h.delay > 0
+	}
+	sorted := slices.Clone(h.latencies)
+	h.mu.Unlock()
+
+	slices.Sort(sorted)
+	i := int(h.percentile * float64(len(sorted)-1))
+	return sorted[i], true
+}
+
+// hedgeResult is the outcome of one hedged request.
+type hedgeResult struct {
+	resp     *Response
+	err      error
+	hedge    int
+	duration time.Duration
+}
+
+// succeeded reports whether the result ends the hedged attempt.
+func (r hedgeResult) succeeded() bool {
+	return r.err == nil && r.resp.StatusCode < http.StatusInternalServerError
+}
+
+// executeHedged executes one attempt as a set of hedged requests. It returns the first
+// successful response and cancels the other requests. If all requests fail, it returns
+// the last result so that the retry loop can handle it.
+func (c *Client) executeHedged(ctx context.Context, req *http.Request) (*Response, error) {
+	info := CallInfoFromContext(ctx)
+	labels := info.labels(req.Method)
+	hasBody := req.Body != nil && req.Body != http.NoBody
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan hedgeResult, c.hedger.maxAttempts)
+	launch := func(hedge int) error {
+		hedgeInfo := *info
+		hedgeInfo.Hedge = hedge
+		hedgeReq := req.Clone(withCallInfo(ctx, &hedgeInfo))
+
+		if hedge > 0 {
+			if hasBody {
+				if req.GetBody == nil {
+					return fmt.Errorf("request body cannot be recreated")
+				}
+				body, err := req.GetBody()
+				if err != nil {
+					return fmt.Errorf("failed to recreate request body: %w", err)
+				}
+				hedgeReq.Body = body
+			}
+			c.metrics.IncHedgedRequest(labels)
+		}
+
+		go func() {
+			start := time.Now()
+			resp, err := c.attemptHandler(hedgeReq.Context(), hedgeReq)
+			results <- hedgeResult{resp: resp, err: err, hedge: hedge, duration: time.Since(start)}
+		}()
+		return nil
+	}
+
+	var timer *time.Timer
+	var timerC <-chan time.Time
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	var sent, pending int
+	startTimer := func() {
+		timerC = nil
+		if sent >= c.hedger.maxAttempts {
+			return
+		}
+		if delay, ok := c.hedger.hedgeDelay(); ok {
+			timer = time.NewTimer(delay)
+			timerC = timer.C
+		}
+	}
+
+	if err := launch(0); err != nil {
+		return nil, err
+	}
+	sent, pending = 1, 1
+	startTimer()
+
+	var last hedgeResult
+	for {
+		select {
+		case r := <-results:
+			pending--
+			if r.succeeded() {
+				c.hedger.observe(r.duration)
+				if r.hedge > 0 {
+					c.metrics.IncHedgeWin(labels)
+				}
+				return r.resp, r.err
+			}
+			last = r
+			if pending == 0 {
+				return last.resp, last.err
+			}
+
+		case <-timerC:
+			if err := launch(sent); err != nil {
+				timerC = nil
+				continue
+			}
+			sent++
+			pending++
+			startTimer()
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHedgingConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config HedgingConfig
+		errMsg string
+	}{
+		{"valid delay", HedgingConfig{Delay: 50 * time.Millisecond}, ""},
+		{"valid percentile", HedgingConfig{Percentile: 0.95}, ""},
+		{"negative max attempts", HedgingConfig{MaxAttempts: -1, Delay: time.Millisecond}, "max attempts cannot be negative"},
+		{"negative delay", HedgingConfig{Delay: -time.Millisecond}, "delay cannot be negative"},
+		{"percentile above 1", HedgingConfig{Percentile: 1.5}, "percentile must be between 0 and 1"},
+		{"no delay or percentile", HedgingConfig{MaxAttempts: 2}, "delay or percentile is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing '%s', got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestHedgerDelay(t *testing.T) {
+	if h := newHedger(nil); h != nil {
+		t.Error("expected nil hedger without config")
+	}
+
+	t.Run("fixed delay", func(t *testing.T) {
+		h := newHedger(&HedgingConfig{Delay: 30 * time.Millisecond})
+		if h.maxAttempts != defaultHedgingMaxAttempts {
+			t.Errorf("expected %d max attempts, got %d", defaultHedgingMaxAttempts, h.maxAttempts)
+		}
+		if d, ok := h.hedgeDelay(); !ok || d != 30*time.Millisecond {
+			t.Errorf("expected 30ms delay, got %v (%v)", d, ok)
+		}
+	})
+
+	t.Run("percentile delay", func(t *testing.T) {
+		h := newHedger(&HedgingConfig{Percentile: 0.9})
+		if _, ok := h.hedgeDelay(); ok {
+			t.Error("expected no hedging before enough latencies are observed")
+		}
+
+		for i := 1; i <= hedgingLatencySamples+10; i++ {
+			h.observe(time.Duration(i) * time.Millisecond)
+		}
+
+		d, ok := h.hedgeDelay()
+		if !ok || d < 90*time.Millisecond || d > 110*time.Millisecond {
+			t.Errorf("expected p90 of recent latencies, got %v (%v)", d, ok)
+		}
+	})
+
+	t.Run("percentile falls back to delay", func(t *testing.T) {
+		h := newHedger(&HedgingConfig{Percentile: 0.95, Delay: 10 * time.Millisecond})
+		if d, ok := h.hedgeDelay(); !ok || d != 10*time.Millisecond {
+			t.Errorf("expected fallback delay of 10ms, got %v (%v)", d, ok)
+		}
+	})
+}
+
+func TestClientHedging(t *testing.T) {
+	newClient := func(t *testing.T, serverURL string, metrics Metrics) *Client {
+		t.Helper()
+		client, err := NewClient(&Config{
+			BaseURL:        serverURL,
+			Timeout:        30 * time.Second,
+			RequestTimeout: 10 * time.Second,
+			Hedging:        &HedgingConfig{Delay: 20 * time.Millisecond},
+			Metrics:        metrics,
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		return client
+	}
+
+	// slowFirst stalls the first request until the client gives up on it.
+	slowFirst := func(calls *int32) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(calls, 1) == 1 {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(2 * time.Second):
+				}
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+	}
+
+	t.Run("returns the first successful response", func(t *testing.T) {
+		var calls int32
+		server := slowFirst(&calls)
+		defer server.Close()
+
+		metrics := &recordingMetrics{}
+		client := newClient(t, server.URL, metrics)
+
+		start := time.Now()
+		resp, err := client.Get(context.Background(), "/drivers/nearby").WithHedging().Do()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected status 200, got %d", resp.StatusCode)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("expected the hedged request to answer, took %v", elapsed)
+		}
+		if atomic.LoadInt32(&calls) != 2 {
+			t.Errorf("expected 2 requests, got %d", calls)
+		}
+
+		metrics.mu.Lock()
+		defer metrics.mu.Unlock()
+		if metrics.hedgedRequests != 1 || metrics.hedgeWins != 1 {
+			t.Errorf("expected 1 hedged request and 1 win, got %d and %d", metrics.hedgedRequests, metrics.hedgeWins)
+		}
+	})
+
+	t.Run("does not hedge without opt-in", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			time.Sleep(50 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := newClient(t, server.URL, nil)
+		if _, err := client.Get(context.Background(), "/test").Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("expected 1 request, got %d", calls)
+		}
+	})
+
+	t.Run("does not hedge non-idempotent requests", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			time.Sleep(50 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := newClient(t, server.URL, nil)
+		if _, err := client.Post(context.Background(), "/rides", map[string]int{"x": 1}).WithHedging().Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("expected 1 request, got %d", calls)
+		}
+	})
+
+	t.Run("hedges POST marked idempotent with body", func(t *testing.T) {
+		var calls int32
+		var bodies [2]string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&calls, 1)
+			buf := make([]byte, 64)
+			m, _ := r.Body.Read(buf)
+			if n <= 2 {
+				bodies[n-1] = string(buf[:m])
+			}
+			if n == 1 {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(2 * time.Second):
+				}
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := newClient(t, server.URL, nil)
+		_, err := client.Post(context.Background(), "/pricing/estimate", map[string]int{"x": 1}).
+			WithIdempotent().
+			WithHedging().
+			Do()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if atomic.LoadInt32(&calls) != 2 {
+			t.Errorf("expected 2 requests, got %d", calls)
+		}
+		if bodies[1] != `{"x":1}` {
+			t.Errorf("expected hedged request to carry the body, got %q", bodies[1])
+		}
+	})
+
+	t.Run("returns the last failure", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(30 * time.Millisecond)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client := newClient(t, server.URL, nil)
+		resp, err := client.Get(context.Background(), "/test").WithHedging().WithNoRetry().Do()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("expected status 503, got %d", resp.StatusCode)
+		}
+	})
+}
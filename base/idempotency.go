@@ -0,0 +1,87 @@
+package base
+
+import (
+	"crypto/rand"
+	"net/http"
+)
+
+// DefaultIdempotencyHeader is the default header carrying the idempotency key.
+const DefaultIdempotencyHeader = "Idempotency-Key"
+
+// IdempotencyConfig holds configuration for automatic idempotency keys.
+// A generated key is sent with every attempt of a logical call, so the server
+// can deduplicate retried mutations.
+type IdempotencyConfig struct {
+	// Header is the header carrying the key (default: "Idempotency-Key").
+	Header string
+
+	// Methods are the HTTP methods that get a generated key (default: POST, PATCH).
+	Methods []string
+
+	// KeyFunc generates a new key (default: NewIdempotencyKey).
+	KeyFunc func() string
+}
+
+// NewIdempotencyKey returns a new random idempotency key.
+func NewIdempotencyKey() string {
+	return rand.Text()
+}
+
+// idempotency applies idempotency keys to requests.
+type idempotency struct {
+	header  string
+	methods map[string]bool
+	keyFunc func() string
+}
+
+// newIdempotency creates an idempotency from the config.
+// If cfg is nil, keys are only sent when set explicitly on a request.
+func newIdempotency(cfg *IdempotencyConfig) *idempotency {
+	i := &idempotency{
+		header:  DefaultIdempotencyHeader,
+		methods: make(map[string]bool),
+		keyFunc: NewIdempotencyKey,
+	}
+	if cfg == nil {
+		return i
+	}
+
+	if cfg.Header != "" {
+		i.header = cfg.Header
+	}
+	if cfg.KeyFunc != nil {
+		i.keyFunc = cfg.KeyFunc
+	}
+
+	methods := cfg.Methods
+	if len(methods) == 0 {
+		methods = []string{http.MethodPost, http.MethodPatch}
+	}
+	for _, m := range methods {
+		i.methods[m] = true
+	}
+
+	return i
+}
+
+// apply sets the idempotency key header on the request and returns the key.
+// An explicit key takes precedence over a header already set on the request,
+// which takes precedence over a generated key. It returns "" if no key applies.
+func (i *idempotency) apply(req *http.Request, key string) string {
+	if key != "" {
+		req.Header.Set(i.header, key)
+		return key
+	}
+
+	if key := req.Header.Get(i.header); key != "" {
+		return key
+	}
+
+	if !i.methods[req.Method] {
+		return ""
+	}
+
+	key = i.keyFunc()
+	req.Header.Set(i.header, key)
+	return key
+}
@@ -0,0 +1,116 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewIdempotencyKey(t *testing.T) {
+	a, b := NewIdempotencyKey(), NewIdempotencyKey()
+	if a == "" || a == b {
+		t.Errorf("expected unique non-empty keys, got %q and %q", a, b)
+	}
+}
+
+func TestIdempotencyApply(t *testing.T) {
+	newRequest := func(method string) *http.Request {
+		req, _ := http.NewRequestWithContext(context.Background(), method, "http://localhost/test", nil)
+		return req
+	}
+
+	t.Run("disabled without config", func(t *testing.T) {
+		i := newIdempotency(nil)
+		req := newRequest(http.MethodPost)
+
+		if key := i.apply(req, ""); key != "" {
+			t.Errorf("expected no key, got %q", key)
+		}
+		if key := i.apply(req, "explicit"); key != "explicit" || req.Header.Get(DefaultIdempotencyHeader) != "explicit" {
+			t.Errorf("expected explicit key to be set, got %q", key)
+		}
+	})
+
+	t.Run("generates keys for configured methods", func(t *testing.T) {
+		i := newIdempotency(&IdempotencyConfig{KeyFunc: func() string { return "generated" }})
+
+		post := newRequest(http.MethodPost)
+		if key := i.apply(post, ""); key != "generated" || post.Header.Get(DefaultIdempotencyHeader) != "generated" {
+			t.Errorf("expected generated key for POST, got %q", key)
+		}
+
+		get := newRequest(http.MethodGet)
+		if key := i.apply(get, ""); key != "" || get.Header.Get(DefaultIdempotencyHeader) != "" {
+			t.Errorf("expected no key for GET, got %q", key)
+		}
+	})
+
+	t.Run("keeps existing header", func(t *testing.T) {
+		i := newIdempotency(&IdempotencyConfig{Header: "X-Idempotency-Key", Methods: []string{http.MethodPut}})
+		req := newRequest(http.MethodPut)
+		req.Header.Set("X-Idempotency-Key", "existing")
+
+		if key := i.apply(req, ""); key != "existing" {
+			t.Errorf("expected existing key, got %q", key)
+		}
+	})
+}
+
+func TestClientIdempotencyKey(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		keys []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		keys = append(keys, r.Header.Get(DefaultIdempotencyHeader))
+		if len(keys)%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{
+		BaseURL:        server.URL,
+		Timeout:        30 * time.Second,
+		RequestTimeout: 10 * time.Second,
+		Retry: RetryConfig{
+			MaxRetries:  1,
+			InitialWait: time.Millisecond,
+			MaxWait:     time.Millisecond,
+			Multiplier:  1.0,
+		},
+		Idempotency: &IdempotencyConfig{},
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := client.Post(context.Background(), "/refunds", map[string]int{"amount": 1}).Do(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Post(context.Background(), "/refunds", map[string]int{"amount": 1}).
+		WithIdempotencyKey("refund-1").
+		Do(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(keys) != 4 {
+		t.Fatalf("expected 4 attempts, got %d", len(keys))
+	}
+	if keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("expected generated key to be reused across attempts, got %v", keys[:2])
+	}
+	if keys[2] != "refund-1" || keys[3] != "refund-1" {
+		t.Errorf("expected explicit key on both attempts, got %v", keys[2:])
+	}
+	if keys[0] == keys[2] {
+		t.Error("expected a different key per logical call")
+	}
+}
@@ -0,0 +1,92 @@
+package base
+
+import (
+	"fmt"
+	"io"
+)
+
+// DefaultMaxResponseSize is the default maximum size of a buffered response body (10 MiB).
+const DefaultMaxResponseSize int64 = 10 << 20
+
+// ResponseLimit returns the response size limit for a configured MaxResponseSize:
+// n itself, or DefaultMaxResponseSize if n is zero. A negative n is an error.
+// It is used by the external clients to validate their MaxResponseSize.
+func ResponseLimit(n int64) (int64, error) {
+	if n < 0 {
+		return 0, fmt.Errorf("max response size cannot be negative")
+	}
+	if n == 0 {
+		return DefaultMaxResponseSize, nil
+	}
+	return n, nil
+}
+
+// ReadBody reads r to the end, failing with a response too large error once more than
+// limit bytes have been read. A limit of zero or less reads without a limit.
+// It is used by the external clients, which read their response bodies themselves.
+func ReadBody(r io.Reader, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		return io.ReadAll(r)
+	}
+
+	// Read one byte past the limit to tell a body of exactly limit bytes from a larger one.
+	body, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, ErrResponseTooLarge(limit)
+	}
+	return body, nil
+}
+
+// readResponseBody reads a buffered response body with the given limit.
+func readResponseBody(r io.Reader, limit int64) ([]byte, error) {
+	body, err := ReadBody(r, limit)
+	if err != nil {
+		if IsResponseTooLarge(err) {
+			return nil, err
+		}
+		return nil, ErrBadGatewayWrap("failed to read response body", err)
+	}
+	return body, nil
+}
+
+// limitedBody is a streamed response body that fails once more than limit bytes are read.
+type limitedBody struct {
+	io.ReadCloser
+	limit     int64
+	remaining int64
+}
+
+// newLimitedBody wraps body so that reading more than limit bytes fails.
+func newLimitedBody(body io.ReadCloser, limit int64) *limitedBody {
+	return &limitedBody{ReadCloser: body, limit: limit, remaining: limit}
+}
+
+// Read implements io.Reader.
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, ErrResponseTooLarge(b.limit)
+	}
+
+	// Allow one byte past the limit to tell a body of exactly limit bytes from a larger one.
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n + int(b.remaining), ErrResponseTooLarge(b.limit)
+	}
+	return n, err
+}
+
+// bodyLimit returns the maximum response size for a call: the limit set with
+// Request.WithMaxResponseSize, or the client's MaxResponseSize.
+func (c *Client) bodyLimit(info *CallInfo) int64 {
+	if info != nil && info.responseLimit > 0 {
+		return info.responseLimit
+	}
+	return c.responseLimit
+}
@@ -0,0 +1,152 @@
+package base
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReadBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		limit   int64
+		wantErr bool
+	}{
+		{name: "below limit", body: "hello", limit: 10},
+		{name: "at limit", body: "hello", limit: 5},
+		{name: "above limit", body: "hello!", limit: 5, wantErr: true},
+		{name: "no limit", body: "hello", limit: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := ReadBody(strings.NewReader(tt.body), tt.limit)
+			if tt.wantErr {
+				if !IsResponseTooLarge(err) {
+					t.Errorf("expected response too large error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(body) != tt.body {
+				t.Errorf("expected body %q, got %q", tt.body, body)
+			}
+		})
+	}
+}
+
+func TestResponseLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int64
+		expected int64
+		wantErr  bool
+	}{
+		{name: "default", size: 0, expected: DefaultMaxResponseSize},
+		{name: "configured", size: 1024, expected: 1024},
+		{name: "negative", size: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, err := ResponseLimit(tt.size)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error for negative size")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if limit != tt.expected {
+				t.Errorf("expected limit %d, got %d", tt.expected, limit)
+			}
+		})
+	}
+}
+
+func TestClientMaxResponseSize(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		_, _ = io.WriteString(w, strings.Repeat("x", 100))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{
+		BaseURL:         server.URL,
+		MaxResponseSize: 64,
+		Retry:           RetryConfig{MaxRetries: 2, InitialWait: time.Millisecond, MaxWait: time.Millisecond, Multiplier: 1.0},
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	t.Run("fails without retrying", func(t *testing.T) {
+		hits.Store(0)
+		_, err := client.Get(context.Background(), "/exports").Do()
+		if !IsResponseTooLarge(err) {
+			t.Errorf("expected response too large error, got %v", err)
+		}
+		if got := hits.Load(); got != 1 {
+			t.Errorf("expected 1 call, got %d", got)
+		}
+	})
+
+	t.Run("request limit overrides client limit", func(t *testing.T) {
+		resp, err := client.Get(context.Background(), "/exports").WithMaxResponseSize(128).Do()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resp.Body) != 100 {
+			t.Errorf("expected 100 bytes, got %d", len(resp.Body))
+		}
+	})
+
+	t.Run("rejects non-positive request limit", func(t *testing.T) {
+		if _, err := client.Get(context.Background(), "/exports").WithMaxResponseSize(0).Do(); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+
+	t.Run("streamed body is not limited by default", func(t *testing.T) {
+		resp, err := client.Get(context.Background(), "/exports").Stream()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer resp.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(body) != 100 {
+			t.Errorf("expected 100 bytes, got %d", len(body))
+		}
+	})
+
+	t.Run("streamed body is limited by the request limit", func(t *testing.T) {
+		resp, err := client.Get(context.Background(), "/exports").WithMaxResponseSize(80).Stream()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer resp.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if !IsResponseTooLarge(err) {
+			t.Errorf("expected response too large error, got %v", err)
+		}
+		if len(body) != 80 {
+			t.Errorf("expected 80 bytes before the error, got %d", len(body))
+		}
+	})
+}
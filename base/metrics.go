@@ -0,0 +1,158 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RequestLabels identifies a logical call for metrics.
+type RequestLabels struct {
+	// Service is the name of the downstream service.
+	Service string
+
+	// Method is the HTTP method.
+	Method string
+
+	// Route is the request path template (e.g. "/rides/{id}").
+	Route string
+}
+
+// Metrics records client, retry, circuit breaker, bulkhead, rate limiter, cache and coalescing metrics.
+// Implementations should embed NopMetrics so that new methods do not break them.
+type Metrics interface {
+	// ObserveRequest records a completed logical call. statusCode is 0 if err is set.
+	ObserveRequest(labels RequestLabels, statusCode int, duration time.Duration, err error)
+
+	// ObserveAttempts records the number of attempts made for a logical call.
+	ObserveAttempts(labels RequestLabels, attempts int)
+
+	// IncRetry records a retry and its reason (e.g. "status_503" or "network_error").
+	IncRetry(labels RequestLabels, reason string)
+
+	// IncRetryBudgetExhausted records a retry skipped because the retry budget is exhausted.
+	IncRetryBudgetExhausted(labels RequestLabels)
+
+	// IncHedgedRequest records a hedged request sent in addition to the original.
+	IncHedgedRequest(labels RequestLabels)
+
+	// IncHedgeWin records a call answered by a hedged request rather than the original.
+	IncHedgeWin(labels RequestLabels)
+
+	// SetCircuitState records the current circuit breaker state of a service.
+	SetCircuitState(service string, state CircuitState)
+
+	// IncCircuitOpenRejection records a call rejected because the circuit breaker is open.
+	IncCircuitOpenRejection(service string)
+
+	// SetBulkheadLimit records the current concurrency limit of a service.
+	SetBulkheadLimit(service string, limit int)
+
+	// IncBulkheadRejection records a call rejected because the concurrency limit is reached.
+	IncBulkheadRejection(service string)
+
+	// ObserveRateLimitWait records how long a request waited for a rate limiter token.
+	ObserveRateLimitWait(service string, wait time.Duration)
+
+	// IncRateLimitRejection records a request rejected by the client-side rate limiter.
+	IncRateLimitRejection(service string)
+
+	// IncCacheLookup records a response cache lookup and its result
+	// ("hit", "miss", "revalidated", "stale" or "bypass").
+	IncCacheLookup(labels RequestLabels, result string)
+
+	// IncCoalescedCall records a call answered by an identical call already in flight.
+	IncCoalescedCall(labels RequestLabels)
+}
+
+// NopMetrics is a Metrics implementation that discards everything.
+type NopMetrics struct{}
+
+// ObserveRequest implements Metrics.
+func (NopMetrics) ObserveRequest(RequestLabels, int, time.Duration, error) {}
+
+// ObserveAttempts implements Metrics.
+func (NopMetrics) ObserveAttempts(RequestLabels, int) {}
+
+// IncRetry implements Metrics.
+func (NopMetrics) IncRetry(RequestLabels, string) {}
+
+// IncRetryBudgetExhausted implements Metrics.
+func (NopMetrics) IncRetryBudgetExhausted(RequestLabels) {}
+
+// IncHedgedRequest implements Metrics.
+func (NopMetrics) IncHedgedRequest(RequestLabels) {}
+
+// IncHedgeWin implements Metrics.
+func (NopMetrics) IncHedgeWin(RequestLabels) {}
+
+// SetCircuitState implements Metrics.
+func (NopMetrics) SetCircuitState(string, CircuitState) {}
+
+// IncCircuitOpenRejection implements Metrics.
+func (NopMetrics) IncCircuitOpenRejection(string) {}
+
+// SetBulkheadLimit implements Metrics.
+func (NopMetrics) SetBulkheadLimit(string, int) {}
+
+// IncBulkheadRejection implements Metrics.
+func (NopMetrics) IncBulkheadRejection(string) {}
+
+// ObserveRateLimitWait implements Metrics.
+func (NopMetrics) ObserveRateLimitWait(string, time.Duration) {}
+
+// IncRateLimitRejection implements Metrics.
+func (NopMetrics) IncRateLimitRejection(string) {}
+
+// IncCacheLookup implements Metrics.
+func (NopMetrics) IncCacheLookup(RequestLabels, string) {}
+
+// IncCoalescedCall implements Metrics.
+func (NopMetrics) IncCoalescedCall(RequestLabels) {}
+
+// labels returns the metric labels for the call.
+func (i *CallInfo) labels(method string) RequestLabels {
+	return RequestLabels{
+		Service: i.Service,
+		Method:  method,
+		Route:   i.Route,
+	}
+}
+
+// MetricsMiddleware records the outcome, latency and attempt count of every call
+// using the client Metrics.
+func MetricsMiddleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *http.Request) (*Response, error) {
+			info := CallInfoFromContext(ctx)
+			if info == nil {
+				return next(ctx, req)
+			}
+
+			start := time.Now()
+			resp, err := next(ctx, req)
+
+			labels := info.labels(req.Method)
+			statusCode := 0
+			if resp != nil {
+				statusCode = resp.StatusCode
+			}
+			info.client.metrics.ObserveRequest(labels, statusCode, time.Since(start), err)
+			if info.attempts > 0 {
+				info.client.metrics.ObserveAttempts(labels, info.attempts)
+			}
+
+			return resp, err
+		}
+	}
+}
+
+// retryReason returns a low-cardinality reason label for a retry.
+// A nil response means the attempt failed with a network error.
+func retryReason(resp *http.Response) string {
+	if resp == nil {
+		return "network_error"
+	}
+	return "status_" + strconv.Itoa(resp.StatusCode)
+}
@@ -0,0 +1,266 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// recordingMetrics is a Metrics implementation that records calls for assertions.
+type recordingMetrics struct {
+	NopMetrics
+
+	mu              sync.Mutex
+	requests        []RequestLabels
+	statusCodes     []int
+	attempts        []int
+	retryReasons    []string
+	budgetExhausted int
+	hedgedRequests  int
+	hedgeWins       int
+	circuitStates   []CircuitState
+	circuitLabels   []string
+	circuitRejects  int
+	bulkheadLimits  []int
+	bulkheadRejects int
+	rateLimitWaits  []time.Duration
+	rateLimitDenied int
+	cacheLookups    []string
+	coalescedCalls  int
+}
+
+func (m *recordingMetrics) ObserveRequest(labels RequestLabels, statusCode int, _ time.Duration, _ error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, labels)
+	m.statusCodes = append(m.statusCodes, statusCode)
+}
+
+func (m *recordingMetrics) ObserveAttempts(_ RequestLabels, attempts int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.attempts = append(m.attempts, attempts)
+}
+
+func (m *recordingMetrics) IncRetry(_ RequestLabels, reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retryReasons = append(m.retryReasons, reason)
+}
+
+func (m *recordingMetrics) IncRetryBudgetExhausted(RequestLabels) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.budgetExhausted++
+}
+
+func (m *recordingMetrics) IncHedgedRequest(RequestLabels) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hedgedRequests++
+}
+
+func (m *recordingMetrics) IncHedgeWin(RequestLabels) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hedgeWins++
+}
+
+func (m *recordingMetrics) SetCircuitState(service string, state CircuitState) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.circuitStates = append(m.circuitStates, state)
+	m.circuitLabels = append(m.circuitLabels, service)
+}
+
+func (m *recordingMetrics) IncCircuitOpenRejection(string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.circuitRejects++
+}
+
+func (m *recordingMetrics) SetBulkheadLimit(_ string, limit int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bulkheadLimits = append(m.bulkheadLimits, limit)
+}
+
+func (m *recordingMetrics) IncBulkheadRejection(string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bulkheadRejects++
+}
+
+func (m *recordingMetrics) ObserveRateLimitWait(_ string, wait time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rateLimitWaits = append(m.rateLimitWaits, wait)
+}
+
+func (m *recordingMetrics) IncRateLimitRejection(string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rateLimitDenied++
+}
+
+func (m *recordingMetrics) IncCacheLookup(_ RequestLabels, result string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cacheLookups = append(m.cacheLookups, result)
+}
+
+func (m *recordingMetrics) IncCoalescedCall(RequestLabels) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.coalescedCalls++
+}
+
+func TestRetryReason(t *testing.T) {
+	if got := retryReason(nil); got != "network_error" {
+		t.Errorf("expected network_error, got %s", got)
+	}
+	if got := retryReason(&http.Response{StatusCode: http.StatusServiceUnavailable}); got != "status_503" {
+		t.Errorf("expected status_503, got %s", got)
+	}
+}
+
+func TestClientMetrics(t *testing.T) {
+	t.Run("records requests, attempts and retries", func(t *testing.T) {
+		var calls int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		metrics := &recordingMetrics{}
+		client, err := NewClient(&Config{
+			BaseURL:        server.URL,
+			Timeout:        30 * time.Second,
+			RequestTimeout: 10 * time.Second,
+			Retry: RetryConfig{
+				MaxRetries:  2,
+				InitialWait: time.Millisecond,
+				MaxWait:     5 * time.Millisecond,
+				Multiplier:  1.0,
+			},
+			Metrics: metrics,
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		if _, err := client.Get(context.Background(), "/rides/123").WithQuery("x", "1").Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(metrics.requests) != 1 {
+			t.Fatalf("expected 1 observed request, got %d", len(metrics.requests))
+		}
+		labels := metrics.requests[0]
+		if labels.Method != http.MethodGet || labels.Route != "/rides/123" || labels.Service == "" {
+			t.Errorf("unexpected labels: %+v", labels)
+		}
+		if metrics.statusCodes[0] != http.StatusOK {
+			t.Errorf("expected status 200, got %d", metrics.statusCodes[0])
+		}
+		if len(metrics.attempts) != 1 || metrics.attempts[0] != 2 {
+			t.Errorf("expected 2 attempts, got %v", metrics.attempts)
+		}
+		if len(metrics.retryReasons) != 1 || metrics.retryReasons[0] != "status_503" {
+			t.Errorf("expected retry reason status_503, got %v", metrics.retryReasons)
+		}
+	})
+
+	t.Run("records circuit state and rejections", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		metrics := &recordingMetrics{}
+		client, _ := NewClient(&Config{
+			BaseURL:        server.URL,
+			Timeout:        30 * time.Second,
+			RequestTimeout: 10 * time.Second,
+			Retry: RetryConfig{
+				MaxRetries:  1,
+				InitialWait: time.Millisecond,
+				MaxWait:     time.Millisecond,
+				Multiplier:  1.0,
+			},
+			CircuitBreaker: &CircuitBreakerConfig{
+				FailureThreshold: 1,
+				SuccessThreshold: 1,
+				Timeout:          time.Minute,
+			},
+			Metrics: metrics,
+		}, nil)
+
+		_, _ = client.Get(context.Background(), "/test").Do()
+		_, err := client.Get(context.Background(), "/test").Do()
+		if !IsCircuitOpen(err) {
+			t.Fatalf("expected circuit open error, got %v", err)
+		}
+
+		if metrics.circuitRejects != 1 {
+			t.Errorf("expected 1 circuit rejection, got %d", metrics.circuitRejects)
+		}
+		if len(metrics.circuitStates) != 2 || metrics.circuitStates[0] != CircuitClosed || metrics.circuitStates[1] != CircuitOpen {
+			t.Errorf("expected the initial state and one state change, got %v", metrics.circuitStates)
+		}
+		if len(metrics.requests) != 2 || metrics.statusCodes[1] != 0 {
+			t.Errorf("expected rejected call to be observed without status, got %v", metrics.statusCodes)
+		}
+		if len(metrics.attempts) != 1 {
+			t.Errorf("expected attempts only for the call that reached the server, got %v", metrics.attempts)
+		}
+	})
+
+	t.Run("records circuit state changes without calls", func(t *testing.T) {
+		metrics := &recordingMetrics{}
+		client, _ := NewClient(&Config{
+			BaseURL:        "http://localhost:8080",
+			CircuitBreaker: &CircuitBreakerConfig{FailureThreshold: 1, SuccessThreshold: 1, Timeout: time.Minute},
+			Metrics:        metrics,
+		}, nil)
+
+		client.circuitBreaker.RecordFailure()
+		client.circuitBreaker.Reset()
+
+		metrics.mu.Lock()
+		defer metrics.mu.Unlock()
+		expected := []CircuitState{CircuitClosed, CircuitOpen, CircuitClosed}
+		if !slices.Equal(metrics.circuitStates, expected) {
+			t.Errorf("expected states %v, got %v", expected, metrics.circuitStates)
+		}
+	})
+
+	t.Run("labels Client.Do calls with a constant route", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		metrics := &recordingMetrics{}
+		client, _ := NewClient(&Config{BaseURL: server.URL, Metrics: metrics}, nil)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/rides/123", nil)
+		if _, err := client.Do(context.Background(), req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(metrics.requests) != 1 || metrics.requests[0].Route != "other" {
+			t.Errorf("expected route other, got %+v", metrics.requests)
+		}
+	})
+}
@@ -0,0 +1,203 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Handler executes an HTTP request and returns the buffered response.
+type Handler func(ctx context.Context, req *http.Request) (*Response, error)
+
+// Middleware wraps a Handler with additional behavior.
+// It can be used for auth signing, header injection, request mutation or custom metrics.
+type Middleware func(next Handler) Handler
+
+// Chain wraps h with the given middleware. The first middleware is the outermost.
+func Chain(h Handler, middleware ...Middleware) Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		if middleware[i] != nil {
+			h = middleware[i](h)
+		}
+	}
+	return h
+}
+
+// DefaultMiddleware returns the optional built-in call middleware in their default order:
+// logging and request ID propagation. Custom middleware can be added to or replace them.
+func DefaultMiddleware() []Middleware {
+	return []Middleware{
+		LoggingMiddleware(),
+		RequestIDMiddleware(),
+	}
+}
+
+// DefaultAttemptMiddleware returns the optional built-in attempt middleware. There are
+// none: rate limiting and attempt tracing are always installed, see builtinAttemptMiddleware.
+func DefaultAttemptMiddleware() []Middleware {
+	return []Middleware{}
+}
+
+// builtinMiddleware returns the call middleware every client installs inside its custom
+// middleware, in order: tracing, metrics, response caching, request coalescing, concurrency
+// limiting, failover and circuit breaking. Each has no effect unless the client config
+// enables its feature, so a custom middleware list cannot disable them.
+func builtinMiddleware() []Middleware {
+	return []Middleware{
+		TracingMiddleware(),
+		MetricsMiddleware(),
+		CacheMiddleware(),
+		CoalescingMiddleware(),
+		BulkheadMiddleware(),
+		FailoverMiddleware(),
+		CircuitBreakerMiddleware(),
+	}
+}
+
+// builtinAttemptMiddleware returns the attempt middleware every client installs inside its
+// custom attempt middleware: rate limiting and attempt tracing.
+func builtinAttemptMiddleware() []Middleware {
+	return []Middleware{
+		RateLimitMiddleware(),
+		TracingAttemptMiddleware(),
+	}
+}
+
+// CallInfo describes the logical call being executed.
+type CallInfo struct {
+	// Service is the name of the downstream service.
+	Service string
+
+	// Route is the request path template (e.g. "/rides/{id}") used for logging, metrics and
+	// tracing. For requests built without path parameters it is the request path, and for
+	// calls made with Client.Do it is "other".
+	Route string
+
+	// Attempt is the zero-based attempt number. It is only set for attempt middleware.
+	Attempt int
+
+	// StartTime is when the logical call started.
+	StartTime time.Time
+
+	// Hedge is the zero-based hedged request number. It is only set for attempt middleware.
+	Hedge int
+
+	// IdempotencyKey is the idempotency key sent with every attempt, if any.
+	IdempotencyKey string
+
+	// Idempotent reports whether the call is safe to send more than once: it has an
+	// idempotent method, an idempotency key, or was marked with Request.WithIdempotent.
+	Idempotent bool
+
+	client          *Client
+	retryer         *Retryer
+	breaker         *CircuitBreaker
+	endpoint        string
+	template        bool
+	bypassBreaker   bool
+	bypassCache     bool
+	hedge           bool
+	stream          bool
+	responseLimit   int64
+	attempts        int
+	attemptDuration time.Duration
+}
+
+// callInfoKey is the context key for CallInfo.
+type callInfoKey struct{}
+
+// withCallInfo returns a context carrying the given CallInfo.
+func withCallInfo(ctx context.Context, info *CallInfo) context.Context {
+	return context.WithValue(ctx, callInfoKey{}, info)
+}
+
+// CallInfoFromContext returns the CallInfo for the call being executed, or nil
+// if the context does not belong to a base.Client call.
+func CallInfoFromContext(ctx context.Context) *CallInfo {
+	info, _ := ctx.Value(callInfoKey{}).(*CallInfo)
+	return info
+}
+
+// LoggingMiddleware logs the start and completion of every call using the client logger.
+func LoggingMiddleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *http.Request) (*Response, error) {
+			info := CallInfoFromContext(ctx)
+			if info == nil {
+				return next(ctx, req)
+			}
+
+			info.client.logRequestStart(ctx, req)
+
+			resp, err := next(ctx, req)
+
+			statusCode := 0
+			logErr := err
+			if resp != nil {
+				statusCode = resp.StatusCode
+				if logErr == nil && statusCode >= 400 {
+					logErr = MapHTTPStatus(statusCode, resp.Body)
+				}
+			}
+			info.client.logRequest(ctx, req.Method, req.URL.String(), statusCode, time.Since(info.StartTime), logErr)
+
+			return resp, err
+		}
+	}
+}
+
+// RequestIDMiddleware propagates the X-Request-ID and X-Correlation-ID headers from context.
+func RequestIDMiddleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *http.Request) (*Response, error) {
+			addTracingHeaders(ctx, req)
+			return next(ctx, req)
+		}
+	}
+}
+
+// CircuitBreakerMiddleware rejects calls while the client circuit breaker is open
+// and records the outcome of every call that is allowed through. With failover, it uses
+// the circuit breaker of the endpoint the call is sent to.
+// It has no effect if the client has no circuit breaker configured or the call bypasses it.
+func CircuitBreakerMiddleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *http.Request) (*Response, error) {
+			info := CallInfoFromContext(ctx)
+			if info == nil || info.breaker == nil || info.bypassBreaker {
+				return next(ctx, req)
+			}
+
+			cb := info.breaker
+			metrics := info.client.metrics
+
+			if !cb.Allow() {
+				metrics.IncCircuitOpenRejection(info.Service)
+				return nil, ErrCircuitOpen(info.Service)
+			}
+
+			start := time.Now()
+			resp, err := next(ctx, req)
+
+			// Slow-call detection uses the latency of the last attempt, excluding
+			// retry backoff, if the retry loop reported it.
+			duration := info.attemptDuration
+			if duration == 0 {
+				duration = time.Since(start)
+			}
+
+			switch {
+			case err == nil:
+				cb.RecordResult(resp.StatusCode < 500, duration)
+			case ctx.Err() != nil, IsRateLimitExceeded(err):
+				// Neither the caller giving up nor a local rejection says anything about the service health,
+				// but a half-open probe slot must be given back.
+				cb.Release()
+			default:
+				cb.RecordResult(false, duration)
+			}
+
+			return resp, err
+		}
+	}
+}
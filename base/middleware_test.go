@@ -0,0 +1,253 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	txcontext "github.com/Dorico-Dynamics/txova-go-core/context"
+)
+
+func TestChain(t *testing.T) {
+	var order []string
+
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, req *http.Request) (*Response, error) {
+				order = append(order, name+":before")
+				resp, err := next(ctx, req)
+				order = append(order, name+":after")
+				return resp, err
+			}
+		}
+	}
+
+	h := Chain(func(ctx context.Context, req *http.Request) (*Response, error) {
+		order = append(order, "handler")
+		return &Response{StatusCode: http.StatusOK}, nil
+	}, record("first"), nil, record("second"))
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://example.com", http.NoBody)
+	if _, err := h(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"first:before", "second:before", "handler", "second:after", "first:after"}
+	if len(order) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, order)
+			break
+		}
+	}
+}
+
+func TestCallInfoFromContext(t *testing.T) {
+	if info := CallInfoFromContext(context.Background()); info != nil {
+		t.Errorf("expected nil CallInfo outside a call, got %+v", info)
+	}
+}
+
+func TestClientMiddleware(t *testing.T) {
+	t.Run("custom call middleware mutates request", func(t *testing.T) {
+		var receivedAuth string
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			receivedAuth = r.Header.Get("Authorization")
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		signer := func(next Handler) Handler {
+			return func(ctx context.Context, req *http.Request) (*Response, error) {
+				req.Header.Set("Authorization", "Bearer signed")
+				return next(ctx, req)
+			}
+		}
+
+		client, err := NewClient(&Config{
+			BaseURL:        server.URL,
+			Timeout:        30 * time.Second,
+			RequestTimeout: 10 * time.Second,
+			Retry:          RetryConfig{MaxRetries: 0},
+			Middleware:     append(DefaultMiddleware(), signer),
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		if _, err := client.Get(context.Background(), "/test").Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if receivedAuth != "Bearer signed" {
+			t.Errorf("expected Authorization 'Bearer signed', got %q", receivedAuth)
+		}
+	})
+
+	t.Run("attempt middleware runs for every attempt", func(t *testing.T) {
+		var calls int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		var mu sync.Mutex
+		var attempts []int
+
+		observer := func(next Handler) Handler {
+			return func(ctx context.Context, req *http.Request) (*Response, error) {
+				info := CallInfoFromContext(ctx)
+				mu.Lock()
+				attempts = append(attempts, info.Attempt)
+				mu.Unlock()
+				return next(ctx, req)
+			}
+		}
+
+		client, err := NewClient(&Config{
+			BaseURL:        server.URL,
+			Timeout:        30 * time.Second,
+			RequestTimeout: 10 * time.Second,
+			Retry: RetryConfig{
+				MaxRetries:  3,
+				InitialWait: time.Millisecond,
+				MaxWait:     5 * time.Millisecond,
+				Multiplier:  1.0,
+			},
+			AttemptMiddleware: []Middleware{observer},
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		resp, err := client.Get(context.Background(), "/test").Do()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected status 200, got %d", resp.StatusCode)
+		}
+		if len(attempts) != 3 || attempts[0] != 0 || attempts[1] != 1 || attempts[2] != 2 {
+			t.Errorf("expected attempts [0 1 2], got %v", attempts)
+		}
+	})
+
+	t.Run("call middleware can short-circuit", func(t *testing.T) {
+		var calls int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		stub := func(next Handler) Handler {
+			return func(ctx context.Context, req *http.Request) (*Response, error) {
+				return &Response{StatusCode: http.StatusTeapot}, nil
+			}
+		}
+
+		client, _ := NewClient(&Config{
+			BaseURL:        server.URL,
+			Timeout:        30 * time.Second,
+			RequestTimeout: 10 * time.Second,
+			Middleware:     []Middleware{stub},
+		}, nil)
+
+		resp, err := client.Get(context.Background(), "/test").Do()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.StatusCode != http.StatusTeapot {
+			t.Errorf("expected status 418, got %d", resp.StatusCode)
+		}
+		if calls != 0 {
+			t.Errorf("expected no server calls, got %d", calls)
+		}
+	})
+
+	t.Run("empty middleware disables optional built-ins only", func(t *testing.T) {
+		var calls int32
+		var receivedRequestID string
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			receivedRequestID = r.Header.Get(txcontext.HeaderRequestID)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		client, _ := NewClient(&Config{
+			BaseURL:        server.URL,
+			Timeout:        30 * time.Second,
+			RequestTimeout: 10 * time.Second,
+			Retry: RetryConfig{
+				MaxRetries:  1,
+				InitialWait: time.Millisecond,
+				MaxWait:     time.Millisecond,
+				Multiplier:  1.0,
+			},
+			CircuitBreaker: &CircuitBreakerConfig{
+				FailureThreshold: 1,
+				SuccessThreshold: 1,
+				Timeout:          time.Minute,
+			},
+			Middleware: []Middleware{},
+		}, nil)
+
+		ctx := txcontext.WithRequestID(context.Background(), "req-123")
+		if _, err := client.Get(ctx, "/test").Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := client.Get(ctx, "/test").Do(); !IsCircuitOpen(err) {
+			t.Errorf("expected the configured circuit breaker to stay enabled, got %v", err)
+		}
+
+		if calls != 2 {
+			t.Errorf("expected 2 server calls before the circuit opened, got %d", calls)
+		}
+		if receivedRequestID != "" {
+			t.Errorf("expected no X-Request-ID header, got %q", receivedRequestID)
+		}
+	})
+
+	t.Run("circuit breaker middleware records server errors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		client, _ := NewClient(&Config{
+			BaseURL:        server.URL,
+			Timeout:        30 * time.Second,
+			RequestTimeout: 10 * time.Second,
+			Retry: RetryConfig{
+				MaxRetries:  1,
+				InitialWait: time.Millisecond,
+				MaxWait:     time.Millisecond,
+				Multiplier:  1.0,
+			},
+			CircuitBreaker: &CircuitBreakerConfig{
+				FailureThreshold: 2,
+				SuccessThreshold: 1,
+				Timeout:          time.Minute,
+			},
+		}, nil)
+
+		_, _ = client.Get(context.Background(), "/test").Do()
+		if stats := client.CircuitBreakerStats(); stats.ConsecutiveFailures != 1 {
+			t.Errorf("expected 1 consecutive failure per logical call, got %d", stats.ConsecutiveFailures)
+		}
+	})
+}
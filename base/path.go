@@ -0,0 +1,68 @@
+package base
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Param returns a RequestOption that sets a path parameter of the request path template.
+//
+//	client.Get(ctx, "/rides/{id}", base.Param("id", rideID))
+func Param(name string, value any) RequestOption {
+	return func(r *Request) *Request {
+		return r.WithParam(name, value)
+	}
+}
+
+// WithParam sets the value of the {name} placeholder in the request path template.
+// The value is formatted with fmt.Sprint and escaped as a single path segment, so it
+// cannot change the route. Requests with path parameters are logged, measured and traced
+// by their unexpanded template, keeping labels and span names bounded.
+func (r *Request) WithParam(name string, value any) *Request {
+	if r.params == nil {
+		r.params = make(map[string]string)
+	}
+	r.params[name] = fmt.Sprint(value)
+	return r
+}
+
+// expandPath replaces every {name} placeholder of template with its escaped parameter.
+// Every placeholder needs a parameter and every parameter a placeholder. Empty, "." and
+// ".." values are rejected, since they would send the request to another route.
+func expandPath(template string, params map[string]string) (string, error) {
+	for name := range params {
+		if !strings.Contains(template, "{"+name+"}") {
+			return "", fmt.Errorf("unknown path parameter %q", name)
+		}
+	}
+
+	var b strings.Builder
+	rest := template
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			b.WriteString(rest)
+			break
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated path parameter in %q", template)
+		}
+
+		name := rest[start+1 : start+end]
+		value, ok := params[name]
+		if !ok {
+			return "", fmt.Errorf("missing path parameter %q", name)
+		}
+		if value == "" || value == "." || value == ".." {
+			return "", fmt.Errorf("invalid value %q for path parameter %q", value, name)
+		}
+
+		b.WriteString(rest[:start])
+		b.WriteString(url.PathEscape(value))
+		rest = rest[start+end+1:]
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,104 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExpandPath(t *testing.T) {
+	tests := []struct {
+		name      string
+		template  string
+		params    map[string]string
+		expected  string
+		expectErr bool
+	}{
+		{name: "no placeholders", template: "/drivers/nearby", expected: "/drivers/nearby"},
+		{name: "single placeholder", template: "/rides/{id}", params: map[string]string{"id": "r1"}, expected: "/rides/r1"},
+		{name: "several placeholders", template: "/users/{user}/rides/{ride}/cancel", params: map[string]string{"user": "u1", "ride": "r1"}, expected: "/users/u1/rides/r1/cancel"},
+		{name: "repeated placeholder", template: "/a/{id}/b/{id}", params: map[string]string{"id": "x"}, expected: "/a/x/b/x"},
+		{name: "value is escaped", template: "/rides/{id}", params: map[string]string{"id": "r/1?x#y"}, expected: "/rides/r%2F1%3Fx%23y"},
+		{name: "missing parameter", template: "/rides/{id}", expectErr: true},
+		{name: "unknown parameter", template: "/rides", params: map[string]string{"id": "r1"}, expectErr: true},
+		{name: "unterminated placeholder", template: "/rides/{id", params: map[string]string{"id": "r1"}, expectErr: true},
+		{name: "empty value", template: "/rides/{id}", params: map[string]string{"id": ""}, expectErr: true},
+		{name: "dot value", template: "/rides/{id}", params: map[string]string{"id": "."}, expectErr: true},
+		{name: "dot-dot value", template: "/rides/{id}/cancel", params: map[string]string{"id": ".."}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandPath(tt.template, tt.params)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("expected error, got path %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRequestPathParams(t *testing.T) {
+	var rawPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawPath = r.URL.EscapedPath()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	metrics := &recordingMetrics{}
+	client, err := NewClient(&Config{BaseURL: server.URL, Metrics: metrics}, nil)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	t.Run("expands and escapes parameters", func(t *testing.T) {
+		if _, err := client.Get(context.Background(), "/rides/{id}/stops/{n}", Param("id", "r/1"), Param("n", 2)).Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if rawPath != "/rides/r%2F1/stops/2" {
+			t.Errorf("expected escaped path, got %s", rawPath)
+		}
+	})
+
+	t.Run("labels metrics with the template", func(t *testing.T) {
+		metrics.requests = nil
+		if _, err := client.Post(context.Background(), "/rides/{id}/cancel", nil).WithParam("id", "r1").Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(metrics.requests) != 1 || metrics.requests[0].Route != "/rides/{id}/cancel" {
+			t.Errorf("expected route /rides/{id}/cancel, got %+v", metrics.requests)
+		}
+	})
+
+	t.Run("works with typed helpers", func(t *testing.T) {
+		if err := Exec(context.Background(), client, http.MethodDelete, "/rides/{id}", nil, Param("id", "r 1")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if rawPath != "/rides/r%201" {
+			t.Errorf("expected escaped path, got %s", rawPath)
+		}
+	})
+
+	t.Run("rejects invalid parameters before sending", func(t *testing.T) {
+		rawPath = ""
+		if _, err := client.Get(context.Background(), "/rides/{id}", Param("id", "..")).Do(); err == nil {
+			t.Error("expected error, got nil")
+		}
+		if _, err := client.Get(context.Background(), "/rides/{id}").Do(); err == nil {
+			t.Error("expected error for missing parameter, got nil")
+		}
+		if rawPath != "" {
+			t.Errorf("expected no request, got %s", rawPath)
+		}
+	})
+}
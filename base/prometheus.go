@@ -0,0 +1,251 @@
+package base
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Prometheus label names.
+const (
+	labelService = "service"
+	labelMethod  = "method"
+	labelRoute   = "route"
+	labelCode    = "code"
+	labelReason  = "reason"
+	labelResult  = "result"
+)
+
+// PrometheusConfig holds configuration for PrometheusMetrics.
+type PrometheusConfig struct {
+	// Registerer is where the collectors are registered (default: prometheus.DefaultRegisterer).
+	Registerer prometheus.Registerer
+
+	// Namespace is the metric name prefix (default: "txova_client").
+	Namespace string
+
+	// Buckets are the request latency histogram buckets in seconds (default: prometheus.DefBuckets).
+	Buckets []float64
+}
+
+// PrometheusMetrics is a Metrics implementation backed by Prometheus collectors.
+// A single instance can be shared by all clients; calls are labelled by service.
+type PrometheusMetrics struct {
+	requests          *prometheus.CounterVec
+	duration          *prometheus.HistogramVec
+	attempts          *prometheus.HistogramVec
+	retries           *prometheus.CounterVec
+	budgetExhausted   *prometheus.CounterVec
+	hedgedRequests    *prometheus.CounterVec
+	hedgeWins         *prometheus.CounterVec
+	circuitState      *prometheus.GaugeVec
+	circuitRejections *prometheus.CounterVec
+	bulkheadLimit     *prometheus.GaugeVec
+	bulkheadRejects   *prometheus.CounterVec
+	rateLimitWait     *prometheus.HistogramVec
+	rateLimitRejects  *prometheus.CounterVec
+	cacheLookups      *prometheus.CounterVec
+	coalescedCalls    *prometheus.CounterVec
+}
+
+// NewPrometheusMetrics creates and registers the Prometheus collectors.
+func NewPrometheusMetrics(cfg PrometheusConfig) (*PrometheusMetrics, error) {
+	reg := cfg.Registerer
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+
+	namespace := cfg.Namespace
+	if namespace == "" {
+		namespace = "txova_client"
+	}
+
+	buckets := cfg.Buckets
+	if len(buckets) == 0 {
+		buckets = prometheus.DefBuckets
+	}
+
+	m := &PrometheusMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Total number of logical HTTP calls by outcome.",
+		}, []string{labelService, labelMethod, labelRoute, labelCode}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of logical HTTP calls including retries.",
+			Buckets:   buckets,
+		}, []string{labelService, labelMethod, labelRoute}),
+		attempts: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "attempts_per_call",
+			Help:      "Number of attempts made per logical HTTP call.",
+			Buckets:   []float64{1, 2, 3, 4, 5, 8},
+		}, []string{labelService, labelMethod, labelRoute}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "retries_total",
+			Help:      "Total number of retries by reason.",
+		}, []string{labelService, labelMethod, labelRoute, labelReason}),
+		budgetExhausted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "retry_budget_exhausted_total",
+			Help:      "Total number of retries skipped because the retry budget was exhausted.",
+		}, []string{labelService, labelMethod, labelRoute}),
+		hedgedRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "hedged_requests_total",
+			Help:      "Total number of hedged requests sent in addition to the original.",
+		}, []string{labelService, labelMethod, labelRoute}),
+		hedgeWins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "hedge_wins_total",
+			Help:      "Total number of calls answered by a hedged request.",
+		}, []string{labelService, labelMethod, labelRoute}),
+		circuitState: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "circuit_state",
+			Help:      "Current circuit breaker state (0 closed, 1 open, 2 half-open).",
+		}, []string{labelService}),
+		circuitRejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "circuit_open_rejections_total",
+			Help:      "Total number of calls rejected by an open circuit breaker.",
+		}, []string{labelService}),
+		bulkheadLimit: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "bulkhead_limit",
+			Help:      "Current maximum number of concurrent calls.",
+		}, []string{labelService}),
+		bulkheadRejects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "bulkhead_rejections_total",
+			Help:      "Total number of calls rejected because the concurrency limit was reached.",
+		}, []string{labelService}),
+		rateLimitWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "rate_limit_wait_seconds",
+			Help:      "Time requests waited for a client-side rate limiter token.",
+			Buckets:   buckets,
+		}, []string{labelService}),
+		rateLimitRejects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rate_limit_rejections_total",
+			Help:      "Total number of requests rejected by the client-side rate limiter.",
+		}, []string{labelService}),
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_lookups_total",
+			Help:      "Total number of response cache lookups by result.",
+		}, []string{labelService, labelMethod, labelRoute, labelResult}),
+		coalescedCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "coalesced_calls_total",
+			Help:      "Total number of calls answered by an identical call already in flight.",
+		}, []string{labelService, labelMethod, labelRoute}),
+	}
+
+	collectors := []prometheus.Collector{
+		m.requests,
+		m.duration,
+		m.attempts,
+		m.retries,
+		m.budgetExhausted,
+		m.hedgedRequests,
+		m.hedgeWins,
+		m.circuitState,
+		m.circuitRejections,
+		m.bulkheadLimit,
+		m.bulkheadRejects,
+		m.rateLimitWait,
+		m.rateLimitRejects,
+		m.cacheLookups,
+		m.coalescedCalls,
+	}
+	for _, c := range collectors {
+		if err := reg.Register(c); err != nil {
+			return nil, fmt.Errorf("failed to register collector: %w", err)
+		}
+	}
+
+	return m, nil
+}
+
+// ObserveRequest implements Metrics.
+func (m *PrometheusMetrics) ObserveRequest(labels RequestLabels, statusCode int, duration time.Duration, err error) {
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(statusCode)
+	}
+
+	m.requests.WithLabelValues(labels.Service, labels.Method, labels.Route, code).Inc()
+	m.duration.WithLabelValues(labels.Service, labels.Method, labels.Route).Observe(duration.Seconds())
+}
+
+// ObserveAttempts implements Metrics.
+func (m *PrometheusMetrics) ObserveAttempts(labels RequestLabels, attempts int) {
+	m.attempts.WithLabelValues(labels.Service, labels.Method, labels.Route).Observe(float64(attempts))
+}
+
+// IncRetry implements Metrics.
+func (m *PrometheusMetrics) IncRetry(labels RequestLabels, reason string) {
+	m.retries.WithLabelValues(labels.Service, labels.Method, labels.Route, reason).Inc()
+}
+
+// IncRetryBudgetExhausted implements Metrics.
+func (m *PrometheusMetrics) IncRetryBudgetExhausted(labels RequestLabels) {
+	m.budgetExhausted.WithLabelValues(labels.Service, labels.Method, labels.Route).Inc()
+}
+
+// IncHedgedRequest implements Metrics.
+func (m *PrometheusMetrics) IncHedgedRequest(labels RequestLabels) {
+	m.hedgedRequests.WithLabelValues(labels.Service, labels.Method, labels.Route).Inc()
+}
+
+// IncHedgeWin implements Metrics.
+func (m *PrometheusMetrics) IncHedgeWin(labels RequestLabels) {
+	m.hedgeWins.WithLabelValues(labels.Service, labels.Method, labels.Route).Inc()
+}
+
+// SetCircuitState implements Metrics.
+func (m *PrometheusMetrics) SetCircuitState(service string, state CircuitState) {
+	m.circuitState.WithLabelValues(service).Set(float64(state))
+}
+
+// IncCircuitOpenRejection implements Metrics.
+func (m *PrometheusMetrics) IncCircuitOpenRejection(service string) {
+	m.circuitRejections.WithLabelValues(service).Inc()
+}
+
+// SetBulkheadLimit implements Metrics.
+func (m *PrometheusMetrics) SetBulkheadLimit(service string, limit int) {
+	m.bulkheadLimit.WithLabelValues(service).Set(float64(limit))
+}
+
+// IncBulkheadRejection implements Metrics.
+func (m *PrometheusMetrics) IncBulkheadRejection(service string) {
+	m.bulkheadRejects.WithLabelValues(service).Inc()
+}
+
+// ObserveRateLimitWait implements Metrics.
+func (m *PrometheusMetrics) ObserveRateLimitWait(service string, wait time.Duration) {
+	m.rateLimitWait.WithLabelValues(service).Observe(wait.Seconds())
+}
+
+// IncRateLimitRejection implements Metrics.
+func (m *PrometheusMetrics) IncRateLimitRejection(service string) {
+	m.rateLimitRejects.WithLabelValues(service).Inc()
+}
+
+// IncCacheLookup implements Metrics.
+func (m *PrometheusMetrics) IncCacheLookup(labels RequestLabels, result string) {
+	m.cacheLookups.WithLabelValues(labels.Service, labels.Method, labels.Route, result).Inc()
+}
+
+// IncCoalescedCall implements Metrics.
+func (m *PrometheusMetrics) IncCoalescedCall(labels RequestLabels) {
+	m.coalescedCalls.WithLabelValues(labels.Service, labels.Method, labels.Route).Inc()
+}
@@ -0,0 +1,98 @@
+package base
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestNewPrometheusMetrics(t *testing.T) {
+	t.Run("registers collectors", func(t *testing.T) {
+		reg := prometheus.NewRegistry()
+
+		m, err := NewPrometheusMetrics(PrometheusConfig{Registerer: reg})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		labels := RequestLabels{Service: "ride", Method: "GET", Route: "/rides/{id}"}
+		m.ObserveRequest(labels, 200, 50*time.Millisecond, nil)
+		m.ObserveAttempts(labels, 1)
+		m.IncRetry(labels, "status_503")
+		m.IncRetryBudgetExhausted(labels)
+		m.IncHedgedRequest(labels)
+		m.IncHedgeWin(labels)
+		m.SetCircuitState("ride", CircuitOpen)
+		m.IncCircuitOpenRejection("ride")
+		m.SetBulkheadLimit("ride", 20)
+		m.IncBulkheadRejection("ride")
+		m.ObserveRateLimitWait("ride", 50*time.Millisecond)
+		m.IncRateLimitRejection("ride")
+		m.IncCacheLookup(labels, "hit")
+		m.IncCoalescedCall(labels)
+
+		count, err := testutil.GatherAndCount(reg)
+		if err != nil {
+			t.Fatalf("unexpected gather error: %v", err)
+		}
+		if count != 15 {
+			t.Errorf("expected 15 series, got %d", count)
+		}
+	})
+
+	t.Run("fails on duplicate registration", func(t *testing.T) {
+		reg := prometheus.NewRegistry()
+
+		if _, err := NewPrometheusMetrics(PrometheusConfig{Registerer: reg}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := NewPrometheusMetrics(PrometheusConfig{Registerer: reg}); err == nil {
+			t.Error("expected error for duplicate registration")
+		}
+	})
+
+	t.Run("uses custom namespace", func(t *testing.T) {
+		reg := prometheus.NewRegistry()
+
+		m, err := NewPrometheusMetrics(PrometheusConfig{Registerer: reg, Namespace: "custom"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		m.IncCircuitOpenRejection("ride")
+
+		count, err := testutil.GatherAndCount(reg, "custom_circuit_open_rejections_total")
+		if err != nil {
+			t.Fatalf("unexpected gather error: %v", err)
+		}
+		if count != 1 {
+			t.Errorf("expected 1 series, got %d", count)
+		}
+	})
+}
+
+func TestPrometheusMetricsValues(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m, err := NewPrometheusMetrics(PrometheusConfig{Registerer: reg})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	labels := RequestLabels{Service: "pricing", Method: "POST", Route: "/estimates"}
+	m.ObserveRequest(labels, 200, time.Millisecond, nil)
+	m.ObserveRequest(labels, 0, time.Millisecond, errors.New("boom"))
+	m.ObserveRequest(labels, 0, time.Millisecond, errors.New("boom"))
+	m.SetCircuitState("pricing", CircuitHalfOpen)
+
+	if got := testutil.ToFloat64(m.requests.WithLabelValues("pricing", "POST", "/estimates", "200")); got != 1 {
+		t.Errorf("expected 1 successful request, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.requests.WithLabelValues("pricing", "POST", "/estimates", "error")); got != 2 {
+		t.Errorf("expected 2 failed requests, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.circuitState.WithLabelValues("pricing")); got != float64(CircuitHalfOpen) {
+		t.Errorf("expected circuit state %d, got %v", CircuitHalfOpen, got)
+	}
+}
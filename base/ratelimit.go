@@ -0,0 +1,402 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimitMode selects what happens to a call when no token is available.
+type RateLimitMode int
+
+const (
+	// RateLimitModeWait delays the call until a token is available. Calls that would
+	// wait past MaxWait or the call deadline fail immediately instead.
+	RateLimitModeWait RateLimitMode = iota
+	// RateLimitModeFail rejects the call immediately.
+	RateLimitModeFail
+)
+
+// String returns the string representation of the rate limit mode.
+func (m RateLimitMode) String() string {
+	switch m {
+	case RateLimitModeWait:
+		return "wait"
+	case RateLimitModeFail:
+		return "fail"
+	default:
+		return "unknown"
+	}
+}
+
+// Rate limit response headers understood in adaptive mode.
+const (
+	headerRetryAfter         = "Retry-After"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// rateLimitResetEpoch is the X-RateLimit-Reset value above which the header is read as a
+// Unix timestamp rather than a number of seconds.
+const rateLimitResetEpoch = 1_000_000_000
+
+// RateLimitConfig holds client-side rate limiter configuration.
+// The limiter is a token bucket: it allows Rate requests per second on average
+// and bursts of up to Burst requests.
+type RateLimitConfig struct {
+	// Rate is the number of requests per second (required).
+	Rate float64
+
+	// Burst is the maximum number of requests sent at once (default: Rate rounded up).
+	Burst int
+
+	// Mode selects whether calls wait for a token or fail (default: RateLimitModeWait).
+	Mode RateLimitMode
+
+	// MaxWait is the longest a call waits for a token in wait mode.
+	// Zero means calls wait as long as their deadline allows.
+	MaxWait time.Duration
+
+	// Adaptive pauses the limiter when the provider reports its limit is exhausted:
+	// a 429 or 503 response with a Retry-After header, or an X-RateLimit-Remaining
+	// header of 0 with an X-RateLimit-Reset header.
+	Adaptive bool
+
+	// Name is an identifier for this rate limiter (used in errors).
+	// If empty, the client uses the service name.
+	Name string
+}
+
+// Validate validates the rate limit configuration.
+func (c *RateLimitConfig) Validate() error {
+	if c.Rate <= 0 || math.IsInf(c.Rate, 0) || math.IsNaN(c.Rate) {
+		return fmt.Errorf("rate must be positive")
+	}
+
+	if c.Burst < 0 {
+		return fmt.Errorf("burst cannot be negative")
+	}
+
+	if c.MaxWait < 0 {
+		return fmt.Errorf("max wait cannot be negative")
+	}
+
+	switch c.Mode {
+	case RateLimitModeWait, RateLimitModeFail:
+	default:
+		return fmt.Errorf("unknown mode %d", c.Mode)
+	}
+
+	return nil
+}
+
+// RateLimiterStats holds rate limiter statistics.
+type RateLimiterStats struct {
+	Mode  RateLimitMode
+	Rate  float64
+	Burst int
+
+	// Tokens is the number of requests that can be sent now without waiting.
+	// It is negative while calls are waiting for a token.
+	Tokens float64
+	// PausedUntil is when the adaptive pause ends, or zero if the limiter is not paused.
+	PausedUntil time.Time
+	// Rejected is the number of calls rejected since the rate limiter was created.
+	Rejected int64
+}
+
+// RateLimiter is a token bucket rate limiter for outgoing requests.
+// It is safe for concurrent use.
+type RateLimiter struct {
+	name     string
+	rate     float64
+	burst    int
+	mode     RateLimitMode
+	maxWait  time.Duration
+	adaptive bool
+
+	mu          sync.Mutex
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	rejected    int64
+}
+
+// NewRateLimiter creates a new RateLimiter with the given configuration.
+// The bucket starts full.
+func NewRateLimiter(cfg *RateLimitConfig) *RateLimiter {
+	burst := cfg.Burst
+	if burst <= 0 {
+		burst = max(int(math.Ceil(cfg.Rate)), 1)
+	}
+
+	return &RateLimiter{
+		name:     cfg.Name,
+		rate:     cfg.Rate,
+		burst:    burst,
+		mode:     cfg.Mode,
+		maxWait:  cfg.MaxWait,
+		adaptive: cfg.Adaptive,
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// Allow takes a token if one is available now and reports whether it did.
+// It never waits, whatever the mode.
+func (l *RateLimiter) Allow() bool {
+	_, ok := l.reserve(time.Now(), 0)
+	if !ok {
+		l.reject()
+	}
+	return ok
+}
+
+// Acquire takes a token for a request. In wait mode it waits until the token is
+// available; in fail mode, or if the wait would exceed MaxWait, it returns a
+// CodeRateLimitExceeded error without waiting. If the context is done, or its
+// deadline would pass before the token is available, it returns a CodeTimeout
+// error without waiting and without counting a rejection.
+func (l *RateLimiter) Acquire(ctx context.Context) error {
+	_, err := l.acquire(ctx)
+	return err
+}
+
+// acquire implements Acquire and returns how long the call waited.
+func (l *RateLimiter) acquire(ctx context.Context) (time.Duration, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, ErrTimeoutWrap("rate limit wait cancelled", err)
+	}
+
+	maxWait := time.Duration(0)
+	byDeadline := false
+	if l.mode == RateLimitModeWait {
+		maxWait = time.Duration(math.MaxInt64)
+		if l.maxWait > 0 {
+			maxWait = l.maxWait
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			if remaining := max(time.Until(deadline), 0); remaining < maxWait {
+				maxWait = remaining
+				byDeadline = true
+			}
+		}
+	}
+
+	wait, ok := l.reserve(time.Now(), maxWait)
+	if !ok {
+		if byDeadline {
+			return 0, ErrTimeoutWrap("rate limit wait exceeds the deadline", context.DeadlineExceeded)
+		}
+		l.reject()
+		return 0, ErrRateLimitExceeded(l.name)
+	}
+
+	if err := sleepContext(ctx, wait); err != nil {
+		l.cancel()
+		return wait, ErrTimeoutWrap("rate limit wait cancelled", err)
+	}
+	return wait, nil
+}
+
+// reserve takes a token that becomes available after the returned wait.
+// It takes nothing and returns false if the wait would exceed maxWait.
+func (l *RateLimiter) reserve(now time.Time, maxWait time.Duration) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.advance(now)
+
+	var wait time.Duration
+	if l.tokens < 1 {
+		wait = time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	}
+	if pause := l.pausedUntil.Sub(now); pause > wait {
+		wait = pause
+	}
+
+	if wait > maxWait {
+		return wait, false
+	}
+
+	l.tokens--
+	return wait, true
+}
+
+// reject counts a call rejected by the rate limit.
+func (l *RateLimiter) reject() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rejected++
+}
+
+// cancel returns the token of a call that gave up waiting.
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens = min(l.tokens+1, float64(l.burst))
+}
+
+// advance refills the bucket for the time elapsed since the last update.
+// It must be called with l.mu held.
+func (l *RateLimiter) advance(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = min(l.tokens+elapsed.Seconds()*l.rate, float64(l.burst))
+		l.last = now
+	}
+}
+
+// Observe adapts the limiter to the rate limit headers of a response.
+// It has no effect unless the limiter is adaptive.
+func (l *RateLimiter) Observe(statusCode int, header http.Header) {
+	if !l.adaptive {
+		return
+	}
+
+	pause := rateLimitPause(statusCode, header)
+	if pause <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := time.Now().Add(pause); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// rateLimitPause returns how long the provider asks clients to stop sending requests,
+// or zero if the response does not report an exhausted limit.
+func rateLimitPause(statusCode int, header http.Header) time.Duration {
+	if statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable {
+		if d := parseRetryAfter(header.Get(headerRetryAfter)); d > 0 {
+			return d
+		}
+	}
+
+	if header.Get(headerRateLimitRemaining) != "0" {
+		return 0
+	}
+
+	reset, err := strconv.ParseInt(header.Get(headerRateLimitReset), 10, 64)
+	if err != nil || reset <= 0 {
+		return 0
+	}
+	if reset < rateLimitResetEpoch {
+		return time.Duration(reset) * time.Second
+	}
+	return time.Until(time.Unix(reset, 0))
+}
+
+// Stats returns the current statistics for the rate limiter.
+func (l *RateLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.advance(now)
+
+	stats := RateLimiterStats{
+		Mode:     l.mode,
+		Rate:     l.rate,
+		Burst:    l.burst,
+		Tokens:   l.tokens,
+		Rejected: l.rejected,
+	}
+	if l.pausedUntil.After(now) {
+		stats.PausedUntil = l.pausedUntil
+	}
+	return stats
+}
+
+// RateLimitMiddleware is attempt middleware that takes a rate limiter token for every
+// attempt and hedged request, and adapts the limiter to the rate limit headers of the
+// response. It has no effect if the client has no rate limiter configured.
+func RateLimitMiddleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *http.Request) (*Response, error) {
+			info := CallInfoFromContext(ctx)
+			if info == nil || info.client.rateLimiter == nil {
+				return next(ctx, req)
+			}
+
+			limiter := info.client.rateLimiter
+			metrics := info.client.metrics
+
+			wait, err := limiter.acquire(ctx)
+			if wait > 0 {
+				metrics.ObserveRateLimitWait(info.Service, wait)
+			}
+			if err != nil {
+				if IsRateLimitExceeded(err) {
+					metrics.IncRateLimitRejection(info.Service)
+				}
+				return nil, err
+			}
+
+			resp, err := next(ctx, req)
+			if err == nil {
+				limiter.Observe(resp.StatusCode, resp.Headers)
+			}
+			return resp, err
+		}
+	}
+}
+
+// RateLimitTransport is an http.RoundTripper that takes a rate limiter token for every
+// request and adapts the limiter to the rate limit headers of every response.
+// It lets clients built directly on http.Client share the base rate limiter.
+type RateLimitTransport struct {
+	// Limiter is the rate limiter applied to every request (required).
+	Limiter *RateLimiter
+
+	// Base is the transport that sends the requests (default: http.DefaultTransport).
+	Base http.RoundTripper
+}
+
+// NewRateLimitTransport validates cfg and returns a RateLimitTransport with a new rate
+// limiter that sends requests through next (default: http.DefaultTransport).
+// If cfg.Name is empty, name is used.
+func NewRateLimitTransport(cfg *RateLimitConfig, name string, next http.RoundTripper) (*RateLimitTransport, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	rlConfig := *cfg
+	if rlConfig.Name == "" {
+		rlConfig.Name = name
+	}
+
+	return &RateLimitTransport{
+		Limiter: NewRateLimiter(&rlConfig),
+		Base:    next,
+	}, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.Limiter.Acquire(req.Context()); err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, err
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err == nil {
+		t.Limiter.Observe(resp.StatusCode, resp.Header)
+	}
+	return resp, err
+}
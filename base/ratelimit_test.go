@@ -0,0 +1,408 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimitConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config RateLimitConfig
+		errMsg string
+	}{
+		{"valid wait", RateLimitConfig{Rate: 10, Burst: 5}, ""},
+		{"valid fail", RateLimitConfig{Rate: 0.5, Mode: RateLimitModeFail, Adaptive: true}, ""},
+		{"missing rate", RateLimitConfig{}, "rate must be positive"},
+		{"negative rate", RateLimitConfig{Rate: -1}, "rate must be positive"},
+		{"negative burst", RateLimitConfig{Rate: 10, Burst: -1}, "burst cannot be negative"},
+		{"negative max wait", RateLimitConfig{Rate: 10, MaxWait: -time.Second}, "max wait cannot be negative"},
+		{"unknown mode", RateLimitConfig{Rate: 10, Mode: RateLimitMode(9)}, "unknown mode"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing '%s', got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestRateLimitModeString(t *testing.T) {
+	tests := []struct {
+		mode     RateLimitMode
+		expected string
+	}{
+		{RateLimitModeWait, "wait"},
+		{RateLimitModeFail, "fail"},
+		{RateLimitMode(9), "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.mode.String(); got != tt.expected {
+			t.Errorf("expected %s, got %s", tt.expected, got)
+		}
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	t.Run("allows bursts up to the burst size", func(t *testing.T) {
+		l := NewRateLimiter(&RateLimitConfig{Rate: 1, Burst: 3})
+
+		for i := 0; i < 3; i++ {
+			if !l.Allow() {
+				t.Fatalf("expected request %d to be allowed", i+1)
+			}
+		}
+		if l.Allow() {
+			t.Error("expected request beyond the burst to be rejected")
+		}
+		if stats := l.Stats(); stats.Rejected != 1 {
+			t.Errorf("expected 1 rejection, got %d", stats.Rejected)
+		}
+	})
+
+	t.Run("burst defaults to the rate rounded up", func(t *testing.T) {
+		if burst := NewRateLimiter(&RateLimitConfig{Rate: 2.5}).Stats().Burst; burst != 3 {
+			t.Errorf("expected burst 3, got %d", burst)
+		}
+		if burst := NewRateLimiter(&RateLimitConfig{Rate: 0.1}).Stats().Burst; burst != 1 {
+			t.Errorf("expected burst 1, got %d", burst)
+		}
+	})
+
+	t.Run("refills tokens over time", func(t *testing.T) {
+		l := NewRateLimiter(&RateLimitConfig{Rate: 1, Burst: 1})
+		now := time.Now()
+
+		if _, ok := l.reserve(now, 0); !ok {
+			t.Fatal("expected first request to be allowed")
+		}
+		if _, ok := l.reserve(now, 0); ok {
+			t.Fatal("expected second request to be rejected")
+		}
+		if _, ok := l.reserve(now.Add(time.Second), 0); !ok {
+			t.Error("expected request to be allowed after one second")
+		}
+	})
+
+	t.Run("wait mode waits for a token", func(t *testing.T) {
+		l := NewRateLimiter(&RateLimitConfig{Rate: 20, Burst: 1})
+		_ = l.Acquire(context.Background())
+
+		start := time.Now()
+		if err := l.Acquire(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+			t.Errorf("expected to wait about 50ms, waited %v", elapsed)
+		}
+	})
+
+	t.Run("wait mode fails if the wait exceeds max wait", func(t *testing.T) {
+		l := NewRateLimiter(&RateLimitConfig{Rate: 1, Burst: 1, MaxWait: 10 * time.Millisecond, Name: "sms"})
+		_ = l.Acquire(context.Background())
+
+		err := l.Acquire(context.Background())
+		if !IsRateLimitExceeded(err) {
+			t.Errorf("expected rate limit exceeded error, got %v", err)
+		}
+	})
+
+	t.Run("wait mode fails if the wait exceeds the deadline", func(t *testing.T) {
+		l := NewRateLimiter(&RateLimitConfig{Rate: 1, Burst: 1})
+		_ = l.Acquire(context.Background())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		err := l.Acquire(ctx)
+		if !IsTimeout(err) {
+			t.Errorf("expected timeout error, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Millisecond {
+			t.Errorf("expected to fail without waiting, waited %v", elapsed)
+		}
+		if rejected := l.Stats().Rejected; rejected != 0 {
+			t.Errorf("expected no rate limit rejection, got %d", rejected)
+		}
+	})
+
+	t.Run("done context fails without taking a token", func(t *testing.T) {
+		l := NewRateLimiter(&RateLimitConfig{Rate: 1, Burst: 1})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if err := l.Acquire(ctx); !IsTimeout(err) {
+			t.Errorf("expected timeout error, got %v", err)
+		}
+		if stats := l.Stats(); stats.Tokens < 0.99 || stats.Rejected != 0 {
+			t.Errorf("expected a full bucket and no rejection, got %+v", stats)
+		}
+	})
+
+	t.Run("cancelled wait returns the token", func(t *testing.T) {
+		l := NewRateLimiter(&RateLimitConfig{Rate: 10, Burst: 1})
+		_ = l.Acquire(context.Background())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+		}()
+
+		if err := l.Acquire(ctx); !IsTimeout(err) {
+			t.Errorf("expected timeout error, got %v", err)
+		}
+		if tokens := l.Stats().Tokens; tokens < -0.5 {
+			t.Errorf("expected the token to be returned, got %v tokens", tokens)
+		}
+	})
+
+	t.Run("fail mode rejects without waiting", func(t *testing.T) {
+		l := NewRateLimiter(&RateLimitConfig{Rate: 100, Burst: 1, Mode: RateLimitModeFail})
+		_ = l.Acquire(context.Background())
+
+		if err := l.Acquire(context.Background()); !IsRateLimitExceeded(err) {
+			t.Errorf("expected rate limit exceeded error, got %v", err)
+		}
+	})
+}
+
+func TestRateLimiterObserve(t *testing.T) {
+	header := func(kv ...string) http.Header {
+		h := make(http.Header)
+		for i := 0; i < len(kv); i += 2 {
+			h.Set(kv[i], kv[i+1])
+		}
+		return h
+	}
+
+	t.Run("pauses on retry after", func(t *testing.T) {
+		l := NewRateLimiter(&RateLimitConfig{Rate: 100, Adaptive: true})
+		l.Observe(http.StatusTooManyRequests, header("Retry-After", "2"))
+
+		paused := l.Stats().PausedUntil
+		if until := time.Until(paused); until < time.Second || until > 2*time.Second {
+			t.Errorf("expected pause of about 2s, got %v", until)
+		}
+		if l.Allow() {
+			t.Error("expected request to be rejected while paused")
+		}
+	})
+
+	t.Run("pauses until the rate limit reset", func(t *testing.T) {
+		l := NewRateLimiter(&RateLimitConfig{Rate: 100, Adaptive: true})
+		l.Observe(http.StatusOK, header("X-RateLimit-Remaining", "0", "X-RateLimit-Reset", "3"))
+
+		if until := time.Until(l.Stats().PausedUntil); until < 2*time.Second || until > 3*time.Second {
+			t.Errorf("expected pause of about 3s, got %v", until)
+		}
+	})
+
+	t.Run("reads an epoch rate limit reset", func(t *testing.T) {
+		l := NewRateLimiter(&RateLimitConfig{Rate: 100, Adaptive: true})
+		reset := strconv.FormatInt(time.Now().Add(5*time.Second).Unix(), 10)
+		l.Observe(http.StatusOK, header("X-RateLimit-Remaining", "0", "X-RateLimit-Reset", reset))
+
+		if until := time.Until(l.Stats().PausedUntil); until < 3*time.Second || until > 5*time.Second {
+			t.Errorf("expected pause of about 5s, got %v", until)
+		}
+	})
+
+	t.Run("ignores remaining requests", func(t *testing.T) {
+		l := NewRateLimiter(&RateLimitConfig{Rate: 100, Adaptive: true})
+		l.Observe(http.StatusOK, header("X-RateLimit-Remaining", "10", "X-RateLimit-Reset", "3"))
+		l.Observe(http.StatusOK, header("Retry-After", "3"))
+
+		if paused := l.Stats().PausedUntil; !paused.IsZero() {
+			t.Errorf("expected no pause, got %v", paused)
+		}
+	})
+
+	t.Run("ignores headers unless adaptive", func(t *testing.T) {
+		l := NewRateLimiter(&RateLimitConfig{Rate: 100})
+		l.Observe(http.StatusTooManyRequests, header("Retry-After", "2"))
+
+		if paused := l.Stats().PausedUntil; !paused.IsZero() {
+			t.Errorf("expected no pause, got %v", paused)
+		}
+	})
+}
+
+func TestClientRateLimit(t *testing.T) {
+	newClient := func(t *testing.T, serverURL string, cfg *RateLimitConfig, retry RetryConfig, metrics Metrics) *Client {
+		t.Helper()
+		client, err := NewClient(&Config{
+			BaseURL:        serverURL,
+			Timeout:        5 * time.Second,
+			RequestTimeout: 5 * time.Second,
+			Retry:          retry,
+			RateLimit:      cfg,
+			Metrics:        metrics,
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		return client
+	}
+	noRetry := RetryConfig{MaxRetries: 0, Multiplier: 1.0}
+
+	t.Run("fail mode rejects calls beyond the rate", func(t *testing.T) {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		metrics := &recordingMetrics{}
+		client := newClient(t, server.URL, &RateLimitConfig{Rate: 1, Burst: 1, Mode: RateLimitModeFail}, DefaultRetryConfig(), metrics)
+
+		if _, err := client.Get(context.Background(), "/sms").Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, err := client.Get(context.Background(), "/sms").Do()
+		if !IsRateLimitExceeded(err) {
+			t.Errorf("expected rate limit exceeded error, got %v", err)
+		}
+
+		if got := requests.Load(); got != 1 {
+			t.Errorf("expected 1 request, got %d", got)
+		}
+		if stats := client.RateLimiterStats(); stats == nil || stats.Rejected != 1 {
+			t.Errorf("unexpected stats: %+v", stats)
+		}
+
+		metrics.mu.Lock()
+		defer metrics.mu.Unlock()
+		if metrics.rateLimitDenied != 1 {
+			t.Errorf("expected 1 rejection, got %d", metrics.rateLimitDenied)
+		}
+	})
+
+	t.Run("wait mode spaces out calls", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		metrics := &recordingMetrics{}
+		client := newClient(t, server.URL, &RateLimitConfig{Rate: 20, Burst: 1}, noRetry, metrics)
+
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			if _, err := client.Get(context.Background(), "/test").Do(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+			t.Errorf("expected calls to take about 100ms, took %v", elapsed)
+		}
+
+		metrics.mu.Lock()
+		defer metrics.mu.Unlock()
+		if len(metrics.rateLimitWaits) != 2 {
+			t.Errorf("expected 2 recorded waits, got %d", len(metrics.rateLimitWaits))
+		}
+	})
+
+	t.Run("adapts to rate limit headers", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "60")
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := newClient(t, server.URL, &RateLimitConfig{Rate: 100, Mode: RateLimitModeFail, Adaptive: true}, noRetry, nil)
+
+		if _, err := client.Get(context.Background(), "/test").Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := client.Get(context.Background(), "/test").Do(); !IsRateLimitExceeded(err) {
+			t.Errorf("expected rate limit exceeded error, got %v", err)
+		}
+	})
+
+	t.Run("rejections do not trip the circuit breaker", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client, err := NewClient(&Config{
+			BaseURL:        server.URL,
+			Retry:          noRetry,
+			RateLimit:      &RateLimitConfig{Rate: 1, Burst: 1, Mode: RateLimitModeFail},
+			CircuitBreaker: &CircuitBreakerConfig{FailureThreshold: 1, SuccessThreshold: 1, Timeout: time.Minute},
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		for i := 0; i < 3; i++ {
+			_, _ = client.Get(context.Background(), "/test").Do()
+		}
+		if state := client.CircuitBreakerStats().State; state != CircuitClosed {
+			t.Errorf("expected closed circuit, got %s", state)
+		}
+	})
+
+	t.Run("no stats without rate limiter", func(t *testing.T) {
+		client := newClient(t, "http://localhost:8080", nil, noRetry, nil)
+		if client.RateLimiterStats() != nil {
+			t.Error("expected nil stats")
+		}
+	})
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	if _, err := NewRateLimitTransport(&RateLimitConfig{}, "provider", nil); err == nil {
+		t.Error("expected error for invalid config")
+	}
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	transport, err := NewRateLimitTransport(&RateLimitConfig{Rate: 100, Mode: RateLimitModeFail, Adaptive: true}, "provider", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	httpClient := &http.Client{Transport: transport}
+
+	resp, err := httpClient.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	_, err = httpClient.Get(server.URL) //nolint:bodyclose // the request is rejected before it is sent
+	if !IsRateLimitExceeded(err) {
+		t.Errorf("expected rate limit exceeded error, got %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+}
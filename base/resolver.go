@@ -0,0 +1,95 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Resolver discovers the endpoints of a service.
+// Each endpoint is a base URL such as "http://10.0.0.5:8080".
+type Resolver interface {
+	Resolve(ctx context.Context) ([]string, error)
+}
+
+// StaticResolver is a Resolver that always returns the same endpoints.
+type StaticResolver []string
+
+// Resolve implements Resolver.
+func (r StaticResolver) Resolve(context.Context) ([]string, error) {
+	return slices.Clone(r), nil
+}
+
+// DNSResolver is a Resolver that looks up the endpoints in DNS, using SRV records
+// if Service is set and A/AAAA records otherwise. It suits Kubernetes headless services.
+type DNSResolver struct {
+	// Host is the DNS name to look up (required).
+	Host string
+
+	// Port is the port of every endpoint found with A/AAAA records (required unless Service is set).
+	Port int
+
+	// Service and Proto select the SRV record _service._proto.Host. Proto defaults to "tcp".
+	Service string
+	Proto   string
+
+	// Scheme is the endpoint URL scheme (default: "http").
+	Scheme string
+
+	// Resolver performs the lookups (default: net.DefaultResolver).
+	Resolver *net.Resolver
+}
+
+// Resolve implements Resolver.
+func (r *DNSResolver) Resolve(ctx context.Context) ([]string, error) {
+	if r.Host == "" {
+		return nil, fmt.Errorf("host is required")
+	}
+
+	resolver := r.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	scheme := r.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+
+	if r.Service != "" {
+		proto := r.Proto
+		if proto == "" {
+			proto = "tcp"
+		}
+
+		_, records, err := resolver.LookupSRV(ctx, r.Service, proto, r.Host)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up SRV records for %s: %w", r.Host, err)
+		}
+
+		endpoints := make([]string, 0, len(records))
+		for _, srv := range records {
+			host := strings.TrimSuffix(srv.Target, ".")
+			endpoints = append(endpoints, scheme+"://"+net.JoinHostPort(host, strconv.Itoa(int(srv.Port))))
+		}
+		return endpoints, nil
+	}
+
+	if r.Port <= 0 {
+		return nil, fmt.Errorf("port is required")
+	}
+
+	addrs, err := resolver.LookupHost(ctx, r.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up %s: %w", r.Host, err)
+	}
+
+	endpoints := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		endpoints = append(endpoints, scheme+"://"+net.JoinHostPort(addr, strconv.Itoa(r.Port)))
+	}
+	return endpoints, nil
+}
@@ -0,0 +1,57 @@
+package base
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestStaticResolver(t *testing.T) {
+	r := StaticResolver{"http://a:8080", "http://b:8080"}
+
+	endpoints, err := r.Resolve(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(endpoints) != 2 || endpoints[0] != "http://a:8080" {
+		t.Errorf("unexpected endpoints: %v", endpoints)
+	}
+
+	endpoints[0] = "http://changed"
+	if r[0] != "http://a:8080" {
+		t.Error("expected Resolve to return a copy")
+	}
+}
+
+func TestDNSResolver(t *testing.T) {
+	t.Run("resolves A records", func(t *testing.T) {
+		r := &DNSResolver{Host: "localhost", Port: 8080}
+
+		endpoints, err := r.Resolve(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(endpoints) == 0 {
+			t.Fatal("expected at least one endpoint")
+		}
+		for _, endpoint := range endpoints {
+			if !strings.HasPrefix(endpoint, "http://") || !strings.HasSuffix(endpoint, ":8080") {
+				t.Errorf("unexpected endpoint: %s", endpoint)
+			}
+		}
+	})
+
+	t.Run("requires a host", func(t *testing.T) {
+		_, err := (&DNSResolver{Port: 8080}).Resolve(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "host is required") {
+			t.Errorf("expected host error, got %v", err)
+		}
+	})
+
+	t.Run("requires a port without SRV", func(t *testing.T) {
+		_, err := (&DNSResolver{Host: "localhost"}).Resolve(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "port is required") {
+			t.Errorf("expected port error, got %v", err)
+		}
+	})
+}
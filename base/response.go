@@ -2,7 +2,9 @@ package base
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
+	"slices"
 )
 
 // Response represents an HTTP response.
@@ -15,6 +17,13 @@ type Response struct {
 
 	// Body contains the raw response body.
 	Body []byte
+
+	// Stale reports whether the response was served from the cache after it expired,
+	// because the call failed or while it is refreshed in the background.
+	Stale bool
+
+	// stream is the unread body of a streamed call.
+	stream io.ReadCloser
 }
 
 // IsSuccess returns true if the response has a 2xx status code.
@@ -63,6 +72,28 @@ func (r *Response) DecodeError() error {
 	return MapHTTPStatus(r.StatusCode, r.Body)
 }
 
+// httpResponse returns a minimal http.Response carrying the status and headers,
+// used for retry decisions.
+func (r *Response) httpResponse() *http.Response {
+	return &http.Response{
+		StatusCode: r.StatusCode,
+		Header:     r.Headers,
+	}
+}
+
+// clone returns a deep copy of the response.
+func (r *Response) clone() *Response {
+	if r == nil {
+		return nil
+	}
+	return &Response{
+		StatusCode: r.StatusCode,
+		Headers:    r.Headers.Clone(),
+		Body:       slices.Clone(r.Body),
+		Stale:      r.Stale,
+	}
+}
+
 // String returns the response body as a string.
 func (r *Response) String() string {
 	return string(r.Body)
@@ -14,6 +14,9 @@ type Retryer struct {
 	config RetryConfig
 }
 
+// noRetryer is a Retryer that never retries.
+var noRetryer = &Retryer{}
+
 // NewRetryer creates a new Retryer with the given configuration.
 func NewRetryer(config RetryConfig) *Retryer {
 	return &Retryer{
@@ -21,7 +24,22 @@ func NewRetryer(config RetryConfig) *Retryer {
 	}
 }
 
+// ShouldRetryRequest determines if a request should be retried using the retry policy.
+// Unlike ShouldRetry, it takes the request method and idempotency key into account.
+func (r *Retryer) ShouldRetryRequest(req *http.Request, resp *http.Response, err error, attempt int) bool {
+	if attempt >= r.config.MaxRetries {
+		return false
+	}
+
+	policy := r.config.Policy
+	if policy == nil {
+		policy = DefaultRetryPolicy()
+	}
+	return policy.ShouldRetry(req, resp, err)
+}
+
 // ShouldRetry determines if a request should be retried based on the response.
+// It ignores the request method; see ShouldRetryRequest.
 func (r *Retryer) ShouldRetry(resp *http.Response, err error, attempt int) bool {
 	// Don't retry if max attempts reached.
 	if attempt >= r.config.MaxRetries {
@@ -46,7 +64,7 @@ func (r *Retryer) ShouldRetry(resp *http.Response, err error, attempt int) bool
 func (r *Retryer) WaitDuration(resp *http.Response, attempt int) time.Duration {
 	// Check for Retry-After header.
 	if resp != nil {
-		if retryAfter := r.parseRetryAfter(resp); retryAfter > 0 {
+		if retryAfter := parseRetryAfter(resp.Header.Get(headerRetryAfter)); retryAfter > 0 {
 			// Cap the Retry-After value at max wait.
 			if retryAfter > r.config.MaxWait {
 				return r.config.MaxWait
@@ -79,8 +97,12 @@ func (r *Retryer) WaitDuration(resp *http.Response, attempt int) time.Duration {
 // Wait waits for the calculated duration, respecting context cancellation.
 // Returns an error if the context is cancelled during the wait.
 func (r *Retryer) Wait(ctx context.Context, resp *http.Response, attempt int) error {
-	duration := r.WaitDuration(resp, attempt)
-	if duration == 0 {
+	return sleepContext(ctx, r.WaitDuration(resp, attempt))
+}
+
+// sleepContext waits for the given duration, respecting context cancellation.
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	if duration <= 0 {
 		return nil
 	}
 
@@ -95,14 +117,9 @@ func (r *Retryer) Wait(ctx context.Context, resp *http.Response, attempt int) er
 	}
 }
 
-// parseRetryAfter parses the Retry-After header value.
+// parseRetryAfter parses a Retry-After header value.
 // It supports both seconds and HTTP-date formats.
-func (r *Retryer) parseRetryAfter(resp *http.Response) time.Duration {
-	if resp == nil {
-		return 0
-	}
-
-	retryAfter := resp.Header.Get("Retry-After")
+func parseRetryAfter(retryAfter string) time.Duration {
 	if retryAfter == "" {
 		return 0
 	}
@@ -126,6 +143,11 @@ func (r *Retryer) parseRetryAfter(resp *http.Response) time.Duration {
 	return 0
 }
 
+// Budget returns the retry budget, or nil if retries are not budgeted.
+func (r *Retryer) Budget() *RetryBudget {
+	return r.config.Budget
+}
+
 // MaxRetries returns the maximum number of retries.
 func (r *Retryer) MaxRetries() int {
 	return r.config.MaxRetries
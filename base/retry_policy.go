@@ -0,0 +1,85 @@
+package base
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+)
+
+// RetryPolicy decides whether a failed attempt may be retried.
+// It is evaluated with the attempt request and either its response or its error;
+// the Retryer enforces the retry limit separately.
+type RetryPolicy interface {
+	ShouldRetry(req *http.Request, resp *http.Response, err error) bool
+}
+
+// RetryPolicyFunc adapts a function to a RetryPolicy.
+type RetryPolicyFunc func(req *http.Request, resp *http.Response, err error) bool
+
+// ShouldRetry implements RetryPolicy.
+func (f RetryPolicyFunc) ShouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	return f(req, resp, err)
+}
+
+// DefaultRetryPolicy returns the method-aware retry policy used when RetryConfig.Policy is nil.
+//
+// Idempotent requests (GET, HEAD, PUT, DELETE, OPTIONS, TRACE), requests carrying an
+// idempotency key and requests marked with Request.WithIdempotent are retried on any
+// network error and on retryable status codes.
+// Other requests, such as POST and PATCH, are only retried if the request was never sent
+// (e.g. connection refused). They are not retried on any status, since even a 429 or 503
+// from a proxy does not prove the service did not process them.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicyFunc(defaultShouldRetry)
+}
+
+// defaultShouldRetry implements DefaultRetryPolicy.
+func defaultShouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	safe := isIdempotentRequest(req)
+
+	if err != nil {
+		return safe || isPreSendError(err)
+	}
+
+	return safe && resp != nil && IsRetryableStatus(resp.StatusCode)
+}
+
+// IsIdempotentMethod returns true if the HTTP method is idempotent per RFC 9110.
+func IsIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet,
+		http.MethodHead,
+		http.MethodPut,
+		http.MethodDelete,
+		http.MethodOptions,
+		http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
+// isIdempotentRequest reports whether the request is safe to send more than once.
+func isIdempotentRequest(req *http.Request) bool {
+	if info := CallInfoFromContext(req.Context()); info != nil {
+		return info.Idempotent
+	}
+	return IsIdempotentMethod(req.Method) || req.Header.Get(DefaultIdempotencyHeader) != ""
+}
+
+// isPreSendError reports whether err happened before the request reached the server,
+// in which case retrying cannot duplicate it.
+func isPreSendError(err error) bool {
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,211 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestIsIdempotentMethod(t *testing.T) {
+	for _, m := range []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions, http.MethodTrace} {
+		if !IsIdempotentMethod(m) {
+			t.Errorf("expected %s to be idempotent", m)
+		}
+	}
+	for _, m := range []string{http.MethodPost, http.MethodPatch, http.MethodConnect} {
+		if IsIdempotentMethod(m) {
+			t.Errorf("expected %s not to be idempotent", m)
+		}
+	}
+}
+
+func TestIsPreSendError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"connection refused", &url.Error{Op: "Post", Err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}}, true},
+		{"dial timeout", &net.OpError{Op: "dial", Err: errors.New("i/o timeout")}, true},
+		{"dns error", &net.DNSError{Err: "no such host", Name: "payment-service"}, true},
+		{"read error", &net.OpError{Op: "read", Err: syscall.ECONNRESET}, false},
+		{"generic error", errors.New("EOF"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isPreSendError(tt.err); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	ambiguous := &net.OpError{Op: "read", Err: syscall.ECONNRESET}
+	refused := &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}
+
+	newRequest := func(method string, key string) *http.Request {
+		req, _ := http.NewRequestWithContext(context.Background(), method, "http://localhost/test", nil)
+		if key != "" {
+			req.Header.Set(DefaultIdempotencyHeader, key)
+		}
+		return req
+	}
+	status := func(code int) *http.Response {
+		return &http.Response{StatusCode: code}
+	}
+
+	tests := []struct {
+		name     string
+		req      *http.Request
+		resp     *http.Response
+		err      error
+		expected bool
+	}{
+		{"GET on ambiguous error", newRequest(http.MethodGet, ""), nil, ambiguous, true},
+		{"PUT on 502", newRequest(http.MethodPut, ""), status(http.StatusBadGateway), nil, true},
+		{"GET on 404", newRequest(http.MethodGet, ""), status(http.StatusNotFound), nil, false},
+		{"POST on ambiguous error", newRequest(http.MethodPost, ""), nil, ambiguous, false},
+		{"POST on connection refused", newRequest(http.MethodPost, ""), nil, refused, true},
+		{"POST with key on ambiguous error", newRequest(http.MethodPost, "key"), nil, ambiguous, true},
+		{"POST on 502", newRequest(http.MethodPost, ""), status(http.StatusBadGateway), nil, false},
+		{"POST on 503", newRequest(http.MethodPost, ""), status(http.StatusServiceUnavailable), nil, false},
+		{"PATCH on 429", newRequest(http.MethodPatch, ""), status(http.StatusTooManyRequests), nil, false},
+		{"POST with key on 503", newRequest(http.MethodPost, "key"), status(http.StatusServiceUnavailable), nil, true},
+		{"POST with key on 504", newRequest(http.MethodPost, "key"), status(http.StatusGatewayTimeout), nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.ShouldRetry(tt.req, tt.resp, tt.err); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRetryerShouldRetryRequest(t *testing.T) {
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://localhost/test", nil)
+
+	var seen int
+	retryer := NewRetryer(RetryConfig{
+		MaxRetries: 2,
+		Policy: RetryPolicyFunc(func(*http.Request, *http.Response, error) bool {
+			seen++
+			return true
+		}),
+	})
+
+	if !retryer.ShouldRetryRequest(req, nil, errors.New("boom"), 0) {
+		t.Error("expected retry below the limit")
+	}
+	if retryer.ShouldRetryRequest(req, nil, errors.New("boom"), 2) {
+		t.Error("expected no retry at the limit")
+	}
+	if seen != 1 {
+		t.Errorf("expected policy to be consulted once, got %d", seen)
+	}
+}
+
+func TestClientRetryPolicy(t *testing.T) {
+	newClient := func(t *testing.T, serverURL string, policy RetryPolicy, attempts *int32) *Client {
+		t.Helper()
+		client, err := NewClient(&Config{
+			BaseURL:        serverURL,
+			Timeout:        30 * time.Second,
+			RequestTimeout: 10 * time.Second,
+			Retry: RetryConfig{
+				MaxRetries:  2,
+				InitialWait: time.Millisecond,
+				MaxWait:     time.Millisecond,
+				Multiplier:  1.0,
+				Policy:      policy,
+			},
+			AttemptMiddleware: []Middleware{func(next Handler) Handler {
+				return func(ctx context.Context, req *http.Request) (*Response, error) {
+					atomic.AddInt32(attempts, 1)
+					return next(ctx, req)
+				}
+			}},
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		return client
+	}
+
+	// dropConnection closes the connection after the request was received.
+	dropConnection := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			_ = conn.Close()
+		}
+	})
+
+	t.Run("does not retry POST on ambiguous error", func(t *testing.T) {
+		server := httptest.NewServer(dropConnection)
+		defer server.Close()
+
+		var attempts int32
+		client := newClient(t, server.URL, nil, &attempts)
+		if _, err := client.Post(context.Background(), "/refunds", map[string]int{"amount": 1}).Do(); err == nil {
+			t.Fatal("expected error")
+		}
+		if attempts != 1 {
+			t.Errorf("expected 1 attempt, got %d", attempts)
+		}
+	})
+
+	t.Run("retries POST with idempotency key", func(t *testing.T) {
+		server := httptest.NewServer(dropConnection)
+		defer server.Close()
+
+		var attempts int32
+		client := newClient(t, server.URL, nil, &attempts)
+		_, _ = client.Post(context.Background(), "/refunds", map[string]int{"amount": 1}).
+			WithIdempotencyKey("refund-1").
+			Do()
+		if attempts != 3 {
+			t.Errorf("expected 3 attempts, got %d", attempts)
+		}
+	})
+
+	t.Run("retries POST on connection refused", func(t *testing.T) {
+		server := httptest.NewServer(dropConnection)
+		serverURL := server.URL
+		server.Close()
+
+		var attempts int32
+		client := newClient(t, serverURL, nil, &attempts)
+		_, _ = client.Post(context.Background(), "/refunds", map[string]int{"amount": 1}).Do()
+		if attempts != 3 {
+			t.Errorf("expected 3 attempts, got %d", attempts)
+		}
+	})
+
+	t.Run("uses custom policy", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+		}))
+		defer server.Close()
+
+		var attempts int32
+		policy := RetryPolicyFunc(func(req *http.Request, resp *http.Response, err error) bool {
+			return resp != nil && resp.StatusCode == http.StatusConflict
+		})
+		client := newClient(t, server.URL, policy, &attempts)
+		_, _ = client.Get(context.Background(), "/test").Do()
+		if attempts != 3 {
+			t.Errorf("expected 3 attempts, got %d", attempts)
+		}
+	})
+}
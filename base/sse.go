@@ -0,0 +1,435 @@
+package base
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server-sent events protocol values.
+const (
+	headerLastEventID       = "Last-Event-ID"
+	contentTypeEventStream  = "text/event-stream"
+	defaultEventType        = "message"
+	maxEventStreamLineBytes = 1 << 20
+)
+
+// Event is a server-sent event.
+type Event struct {
+	// ID is the last event ID set by the stream, sent as Last-Event-ID on reconnection.
+	ID string
+
+	// Type is the event type (default: "message").
+	Type string
+
+	// Data is the event data. Several data lines are joined with newlines.
+	Data string
+}
+
+// Decode decodes the event data as JSON into dest.
+func (e *Event) Decode(dest any) error {
+	if err := json.Unmarshal([]byte(e.Data), dest); err != nil {
+		return ErrBadGatewayWrap("failed to decode event", err)
+	}
+	return nil
+}
+
+// EventReader reads server-sent events from a text/event-stream body.
+type EventReader struct {
+	scanner     *bufio.Scanner
+	idBuffer    string
+	lastEventID string
+	retry       time.Duration
+
+	// onDispatch, if set, is called with the last event ID at every blank line,
+	// including those ending blocks without data.
+	onDispatch func(lastEventID string)
+}
+
+// NewEventReader creates an EventReader reading from r.
+func NewEventReader(r io.Reader) *EventReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxEventStreamLineBytes)
+	return &EventReader{scanner: scanner}
+}
+
+// Next returns the next event. Comments and events without data are skipped, but an id
+// field sets the last event ID once its block ends, with or without data.
+// It returns io.EOF at the end of the stream; an event that is not terminated by
+// a blank line is discarded.
+func (r *EventReader) Next() (*Event, error) {
+	var eventType string
+	var data strings.Builder
+	hasData := false
+
+	for r.scanner.Scan() {
+		line := strings.TrimSuffix(r.scanner.Text(), "\r")
+
+		if line == "" {
+			r.lastEventID = r.idBuffer
+			if r.onDispatch != nil {
+				r.onDispatch(r.lastEventID)
+			}
+			if !hasData {
+				eventType = ""
+				continue
+			}
+			if eventType == "" {
+				eventType = defaultEventType
+			}
+			return &Event{ID: r.lastEventID, Type: eventType, Data: data.String()}, nil
+		}
+
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+			hasData = true
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				r.idBuffer = value
+			}
+		case "retry":
+			// Only ASCII digits are allowed, so signs and spaces are ignored.
+			if isDigits(value) {
+				if ms, err := strconv.Atoi(value); err == nil {
+					r.retry = time.Duration(ms) * time.Millisecond
+				}
+			}
+		}
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// LastEventID returns the last event ID set by the stream.
+func (r *EventReader) LastEventID() string {
+	return r.lastEventID
+}
+
+// Retry returns the reconnection time set by the stream, or zero if it set none.
+func (r *EventReader) Retry() time.Duration {
+	return r.retry
+}
+
+// Subscription is a stream of server-sent events that reconnects when the connection ends.
+// Reconnections send the Last-Event-ID header and wait as the client Retryer does between
+// retries, or as long as the stream's retry field asks. A subscription ends when it is
+// closed, its context is done, the server responds with 204 No Content or a non-retryable
+// status, or MaxRetries reconnections in a row fail.
+type Subscription struct {
+	events chan Event
+	done   chan struct{}
+	cancel context.CancelFunc
+
+	// retry is the reconnection time set by the stream. It is only used by run.
+	retry time.Duration
+
+	mu          sync.Mutex
+	lastEventID string
+	err         error
+}
+
+// Subscribe opens a server-sent events subscription for the request.
+// Each connection goes through the client middleware and circuit breaker like a streamed
+// call, but is not bounded by the call timeout; RequestTimeout bounds each connection
+// attempt until the response headers arrive. The Last-Event-ID header, if set with
+// WithHeader, resumes the stream from that event. An open connection holds a bulkhead
+// slot, like a streamed call, until it ends.
+func (r *Request) Subscribe() (*Subscription, error) {
+	// Build errors are returned now, since every connection would fail the same way.
+	if _, err := r.build(); err != nil {
+		return nil, err
+	}
+
+	retryer := r.opts.retryer
+	if retryer == nil {
+		retryer = r.client.retryer
+	}
+
+	ctx, cancel := context.WithCancel(r.ctx)
+	s := &Subscription{
+		events:      make(chan Event),
+		done:        make(chan struct{}),
+		cancel:      cancel,
+		lastEventID: r.headers.Get(headerLastEventID),
+	}
+	go s.run(ctx, r, retryer)
+	return s, nil
+}
+
+// Events returns the channel of received events. It is closed when the subscription ends.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Err returns the error that ended the subscription, or nil if it was closed or the
+// server ended it. If the request context is done, it returns the context error.
+// It is only meaningful once the Events channel is closed.
+func (s *Subscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+// LastEventID returns the ID of the last received event.
+func (s *Subscription) LastEventID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.lastEventID
+}
+
+// Close ends the subscription and waits until its connection is closed.
+func (s *Subscription) Close() {
+	s.cancel()
+	<-s.done
+}
+
+// fail ends the subscription with err.
+func (s *Subscription) fail(err error) {
+	s.mu.Lock()
+	if s.err == nil {
+		s.err = err
+	}
+	s.mu.Unlock()
+	s.cancel()
+}
+
+// run connects and reconnects until the subscription ends.
+func (s *Subscription) run(ctx context.Context, r *Request, retryer *Retryer) {
+	defer close(s.done)
+	defer close(s.events)
+	defer s.cancel()
+
+	failures := 0
+	for {
+		resp, err := s.connect(ctx, r)
+		if ctx.Err() != nil {
+			s.end(r.ctx)
+			return
+		}
+		switch {
+		case err == nil:
+			// The stream ended or was dropped after connecting; reconnect.
+			failures = 0
+			err = io.ErrUnexpectedEOF
+		case errors.Is(err, errSubscriptionEnded):
+			return
+		case !isReconnectable(resp, err):
+			s.fail(err)
+			return
+		default:
+			failures++
+		}
+
+		if failures > retryer.MaxRetries() {
+			s.fail(err)
+			return
+		}
+
+		wait := s.retry
+		if wait == 0 {
+			var httpResp *http.Response
+			if resp != nil {
+				httpResp = resp.httpResponse()
+			}
+			wait = retryer.WaitDuration(httpResp, max(failures-1, 0))
+		}
+		r.client.logReconnect(ctx, r.path, failures, wait, err)
+		if sleepContext(ctx, wait) != nil {
+			s.end(r.ctx)
+			return
+		}
+	}
+}
+
+// end records the request context error, if any, when the subscription context is done.
+func (s *Subscription) end(parent context.Context) {
+	if err := parent.Err(); err != nil {
+		s.fail(err)
+	}
+}
+
+// errSubscriptionEnded is returned by connect when the server ends the subscription.
+var errSubscriptionEnded = errors.New("subscription ended by server")
+
+// connect opens one connection and delivers its events until it ends. It returns a nil
+// error if the connection ended after the stream was opened, and the response if the
+// connection failed with an error status.
+func (s *Subscription) connect(ctx context.Context, r *Request) (*Response, error) {
+	req, err := r.build()
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", contentTypeEventStream)
+	req.Header.Set("Cache-Control", "no-cache")
+	if id := s.LastEventID(); id != "" {
+		req.Header.Set(headerLastEventID, id)
+	}
+
+	opts := r.callOptions()
+	opts.stream = true
+	opts.subscription = true
+	opts.retryer = noRetryer
+	resp, err := r.client.do(ctx, req, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.stream == nil {
+		switch {
+		case resp.StatusCode == http.StatusNoContent:
+			return nil, errSubscriptionEnded
+		case !resp.IsSuccess():
+			return resp, MapHTTPStatus(resp.StatusCode, resp.Body)
+		default:
+			return nil, ErrBadGateway("event stream response has no body")
+		}
+	}
+	defer resp.stream.Close()
+
+	if resp.StatusCode == http.StatusNoContent {
+		return nil, errSubscriptionEnded
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header("Content-Type")); mediaType != contentTypeEventStream {
+		return nil, ErrBadGateway(fmt.Sprintf("unexpected event stream content type %q", resp.Header("Content-Type")))
+	}
+
+	// The last event ID carries over from the previous connection until the stream sets another.
+	reader := NewEventReader(resp.stream)
+	reader.idBuffer = s.LastEventID()
+	reader.lastEventID = reader.idBuffer
+	reader.onDispatch = func(lastEventID string) {
+		s.mu.Lock()
+		s.lastEventID = lastEventID
+		s.mu.Unlock()
+	}
+	defer func() {
+		if retry := reader.Retry(); retry > 0 {
+			s.retry = retry
+		}
+	}()
+
+	for {
+		event, err := reader.Next()
+		switch {
+		case errors.Is(err, bufio.ErrTooLong):
+			return nil, ErrBadGatewayWrap("event stream line too long", err)
+		case IsResponseTooLarge(err):
+			return nil, err
+		case err != nil:
+			// A dropped connection is reconnected like a stream that ended.
+			return nil, nil
+		}
+
+		select {
+		case s.events <- *event:
+		case <-ctx.Done():
+			return nil, nil
+		}
+	}
+}
+
+// isReconnectable reports whether a failed connection is tried again.
+// Connections that failed with an error status are only tried again for retryable
+// statuses; malformed and oversized streams are not.
+func isReconnectable(resp *Response, err error) bool {
+	if resp != nil {
+		return IsRetryableStatus(resp.StatusCode)
+	}
+
+	return !IsBadGateway(err) && !IsResponseTooLarge(err)
+}
+
+// logReconnect logs a subscription reconnection.
+func (c *Client) logReconnect(ctx context.Context, path string, failures int, wait time.Duration, err error) {
+	if c.logger == nil {
+		return
+	}
+
+	c.logger.DebugContext(ctx, "event stream reconnecting",
+		"path", path,
+		"service", c.serviceName,
+		"failures", failures,
+		"wait_ms", wait.Milliseconds(),
+		"error", err.Error(),
+	)
+}
+
+// TypedEvent is a server-sent event with its data decoded from JSON.
+type TypedEvent[T any] struct {
+	// ID is the last event ID set by the stream.
+	ID string
+
+	// Type is the event type.
+	Type string
+
+	// Data is the decoded event data.
+	Data T
+}
+
+// DecodeEvents returns a channel of the subscription's events with their data decoded
+// from JSON into T. It consumes the subscription's Events channel, and is closed when the
+// subscription ends. An event that cannot be decoded ends the subscription with a bad
+// gateway error, returned by Err.
+func DecodeEvents[T any](s *Subscription) <-chan TypedEvent[T] {
+	out := make(chan TypedEvent[T])
+
+	go func() {
+		defer close(out)
+
+		for event := range s.events {
+			var data T
+			if err := event.Decode(&data); err != nil {
+				s.fail(err)
+				continue
+			}
+
+			select {
+			case out <- TypedEvent[T]{ID: event.ID, Type: event.Type, Data: data}:
+			case <-s.done:
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,358 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Dorico-Dynamics/txova-go-core/errors"
+)
+
+func TestEventReader(t *testing.T) {
+	stream := ": comment\n" +
+		"retry: 250\n" +
+		"id: 1\n" +
+		"event: ride.status\n" +
+		"data: {\"status\":\"accepted\"}\n" +
+		"\n" +
+		"data: first line\r\n" +
+		"data:second line\r\n" +
+		"\r\n" +
+		"id: 3\n" +
+		"\n" +
+		"data: unterminated\n"
+
+	reader := NewEventReader(strings.NewReader(stream))
+
+	event, err := reader.Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.ID != "1" || event.Type != "ride.status" || event.Data != `{"status":"accepted"}` {
+		t.Errorf("unexpected first event: %+v", event)
+	}
+	if reader.Retry() != 250*time.Millisecond {
+		t.Errorf("expected retry 250ms, got %v", reader.Retry())
+	}
+
+	event, err = reader.Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.ID != "1" || event.Type != "message" || event.Data != "first line\nsecond line" {
+		t.Errorf("unexpected second event: %+v", event)
+	}
+
+	if _, err := reader.Next(); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+	if reader.LastEventID() != "3" {
+		t.Errorf("expected last event ID 3, got %s", reader.LastEventID())
+	}
+}
+
+func TestEventReaderLastEventIDAndRetry(t *testing.T) {
+	stream := "id: 1\n" +
+		"data: first\n" +
+		"\n" +
+		"retry: +500\n" +
+		"retry: 1 0\n" +
+		"id: 2\n" +
+		"\n" +
+		"id: 9\n" +
+		"data: unterminated\n"
+
+	var dispatched []string
+	reader := NewEventReader(strings.NewReader(stream))
+	reader.onDispatch = func(id string) { dispatched = append(dispatched, id) }
+
+	if _, err := reader.Next(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := reader.Next(); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+
+	if reader.LastEventID() != "2" {
+		t.Errorf("expected an id-only block to set last event ID 2, got %s", reader.LastEventID())
+	}
+	if strings.Join(dispatched, ",") != "1,2" {
+		t.Errorf("expected dispatches with IDs 1,2, got %v", dispatched)
+	}
+	if reader.Retry() != 0 {
+		t.Errorf("expected non-digit retry values to be ignored, got %v", reader.Retry())
+	}
+}
+
+// writeEvents writes server-sent events with the given IDs and flushes them.
+func writeEvents(w http.ResponseWriter, ids ...int) {
+	for _, id := range ids {
+		_, _ = fmt.Fprintf(w, "id: %d\ndata: {\"seq\":%d}\n\n", id, id)
+	}
+	w.(http.Flusher).Flush()
+}
+
+func TestSubscription(t *testing.T) {
+	t.Run("reconnects with the last event ID", func(t *testing.T) {
+		var connections atomic.Int32
+		lastEventIDs := make(chan string, 2)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Accept") != "text/event-stream" {
+				t.Errorf("unexpected Accept header: %s", r.Header.Get("Accept"))
+			}
+			lastEventIDs <- r.Header.Get("Last-Event-ID")
+
+			w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+			if connections.Add(1) == 1 {
+				_, _ = io.WriteString(w, "retry: 1\n")
+				writeEvents(w, 1, 2)
+				return
+			}
+			writeEvents(w, 3)
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		client, err := NewClient(&Config{BaseURL: server.URL, RequestTimeout: time.Second, Timeout: time.Second}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		sub, err := client.Get(context.Background(), "/rides/r1/events").Subscribe()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer sub.Close()
+
+		for want := 1; want <= 3; want++ {
+			select {
+			case event := <-sub.Events():
+				if event.ID != fmt.Sprint(want) {
+					t.Errorf("expected event %d, got %s", want, event.ID)
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatalf("timed out waiting for event %d", want)
+			}
+		}
+
+		if id := <-lastEventIDs; id != "" {
+			t.Errorf("expected no Last-Event-ID on the first connection, got %s", id)
+		}
+		if id := <-lastEventIDs; id != "2" {
+			t.Errorf("expected Last-Event-ID 2 on reconnection, got %s", id)
+		}
+		if sub.LastEventID() != "3" {
+			t.Errorf("expected last event ID 3, got %s", sub.LastEventID())
+		}
+
+		sub.Close()
+		if _, ok := <-sub.Events(); ok {
+			t.Error("expected events channel to be closed")
+		}
+		if err := sub.Err(); err != nil {
+			t.Errorf("expected no error after close, got %v", err)
+		}
+	})
+
+	t.Run("reconnects with the ID of a block without data", func(t *testing.T) {
+		var connections atomic.Int32
+		lastEventIDs := make(chan string, 2)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lastEventIDs <- r.Header.Get("Last-Event-ID")
+
+			w.Header().Set("Content-Type", "text/event-stream")
+			if connections.Add(1) == 1 {
+				_, _ = io.WriteString(w, "retry: 1\n")
+				writeEvents(w, 1)
+				_, _ = io.WriteString(w, "id: 5\n\n")
+				return
+			}
+			writeEvents(w, 6)
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		client, err := NewClient(&Config{BaseURL: server.URL, RequestTimeout: time.Second, Timeout: time.Second}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		sub, err := client.Get(context.Background(), "/rides/r1/events").Subscribe()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer sub.Close()
+
+		for _, want := range []string{"1", "6"} {
+			select {
+			case event := <-sub.Events():
+				if event.ID != want {
+					t.Errorf("expected event %s, got %s", want, event.ID)
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatalf("timed out waiting for event %s", want)
+			}
+		}
+
+		<-lastEventIDs
+		if id := <-lastEventIDs; id != "5" {
+			t.Errorf("expected Last-Event-ID 5 on reconnection, got %s", id)
+		}
+	})
+
+	t.Run("ends on non-retryable status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client, err := NewClient(&Config{BaseURL: server.URL}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		sub, err := client.Get(context.Background(), "/rides/missing/events").Subscribe()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for range sub.Events() {
+			t.Error("unexpected event")
+		}
+
+		appErr := errors.AsAppError(sub.Err())
+		if appErr == nil {
+			t.Fatalf("expected AppError, got %v", sub.Err())
+		}
+		if appErr.Code() != errors.CodeNotFound {
+			t.Errorf("expected code NOT_FOUND, got %s", appErr.Code())
+		}
+	})
+
+	t.Run("ends when the server responds with no content", func(t *testing.T) {
+		var connections atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			connections.Add(1)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		client, err := NewClient(&Config{BaseURL: server.URL}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		sub, err := client.Get(context.Background(), "/rides/r1/events").Subscribe()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for range sub.Events() {
+			t.Error("unexpected event")
+		}
+
+		if err := sub.Err(); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if got := connections.Load(); got != 1 {
+			t.Errorf("expected 1 connection, got %d", got)
+		}
+	})
+
+	t.Run("gives up after max retries failed reconnections", func(t *testing.T) {
+		var connections atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			connections.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client, err := NewClient(&Config{
+			BaseURL: server.URL,
+			Retry:   RetryConfig{MaxRetries: 2, InitialWait: time.Millisecond, MaxWait: time.Millisecond, Multiplier: 1.0},
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		sub, err := client.Get(context.Background(), "/drivers/d1/locations").Subscribe()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for range sub.Events() {
+			t.Error("unexpected event")
+		}
+
+		if sub.Err() == nil {
+			t.Error("expected error, got nil")
+		}
+		if got := connections.Load(); got != 3 {
+			t.Errorf("expected 3 connections, got %d", got)
+		}
+	})
+
+	t.Run("rejects other content types", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{}`)
+		}))
+		defer server.Close()
+
+		client, err := NewClient(&Config{BaseURL: server.URL}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		sub, err := client.Get(context.Background(), "/rides/r1/events").Subscribe()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for range sub.Events() {
+			t.Error("unexpected event")
+		}
+
+		if !IsBadGateway(sub.Err()) {
+			t.Errorf("expected bad gateway error, got %v", sub.Err())
+		}
+	})
+}
+
+func TestDecodeEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeEvents(w, 1, 2)
+		_, _ = io.WriteString(w, "data: not json\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{BaseURL: server.URL}, nil)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	sub, err := client.Get(context.Background(), "/rides/r1/events").Subscribe()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer sub.Close()
+
+	var seqs []int
+	for event := range DecodeEvents[struct {
+		Seq int `json:"seq"`
+	}](sub) {
+		seqs = append(seqs, event.Data.Seq)
+	}
+
+	if len(seqs) != 2 || seqs[0] != 1 || seqs[1] != 2 {
+		t.Errorf("expected events 1 and 2, got %v", seqs)
+	}
+	if !IsBadGateway(sub.Err()) {
+		t.Errorf("expected bad gateway error, got %v", sub.Err())
+	}
+}
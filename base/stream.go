@@ -0,0 +1,202 @@
+package base
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// StreamResponse is an HTTP response whose body is read as it arrives.
+// The body must be closed.
+type StreamResponse struct {
+	// StatusCode is the HTTP status code.
+	StatusCode int
+
+	// Headers contains the response headers.
+	Headers http.Header
+
+	// Body is the response body.
+	Body io.ReadCloser
+}
+
+// Close closes the response body.
+func (r *StreamResponse) Close() error {
+	return r.Body.Close()
+}
+
+// Header returns the value of a response header.
+func (r *StreamResponse) Header(key string) string {
+	return r.Headers.Get(key)
+}
+
+// NDJSON returns a decoder for a newline-delimited JSON response body.
+func (r *StreamResponse) NDJSON() *NDJSONDecoder {
+	return NewNDJSONDecoder(r.Body)
+}
+
+// cancelOnClose is a response body that cancels its context when closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close implements io.Closer.
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// closeHook is a response body that calls fn once, when it is first closed.
+type closeHook struct {
+	io.ReadCloser
+	once sync.Once
+	fn   func()
+}
+
+// Close implements io.Closer.
+func (b *closeHook) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.fn)
+	return err
+}
+
+// onClose makes the streamed body of the response call fn once when it is closed.
+// Middleware use it to hold resources, such as a bulkhead slot, until the body is read.
+func (r *Response) onClose(fn func()) {
+	r.stream = &closeHook{ReadCloser: r.stream, fn: fn}
+}
+
+// sendStream sends a streaming attempt. RequestTimeout bounds the attempt until the response
+// headers arrive; the body is then left open for the caller. Bodies of non-2xx responses are
+// buffered so that retries and error mapping work as for buffered calls. A 2xx body is
+// only limited in size if the request set WithMaxResponseSize.
+func (c *Client) sendStream(req *http.Request, info *CallInfo) (*Response, error) {
+	ctx, cancel := context.WithCancel(req.Context())
+
+	var timer *time.Timer
+	if timeout := c.httpClient.Timeout; timeout > 0 {
+		timer = time.AfterFunc(timeout, cancel)
+	}
+
+	resp, err := c.streamClient.Do(req.WithContext(ctx))
+	timedOut := timer != nil && !timer.Stop()
+	if err != nil {
+		cancel()
+		if timedOut && req.Context().Err() == nil {
+			return nil, fmt.Errorf("response headers not received within %s: %w", c.httpClient.Timeout, context.DeadlineExceeded)
+		}
+		return nil, err
+	}
+	if timedOut {
+		_ = resp.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("response headers not received within %s: %w", c.httpClient.Timeout, context.DeadlineExceeded)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer cancel()
+		defer resp.Body.Close()
+
+		body, err := readResponseBody(resp.Body, c.bodyLimit(info))
+		if err != nil {
+			return nil, err
+		}
+		return &Response{
+			StatusCode: resp.StatusCode,
+			Headers:    resp.Header,
+			Body:       body,
+		}, nil
+	}
+
+	var body io.ReadCloser = resp.Body
+	if info.responseLimit > 0 {
+		body = newLimitedBody(body, info.responseLimit)
+	}
+
+	return &Response{
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Header,
+		stream:     &cancelOnClose{ReadCloser: body, cancel: cancel},
+	}, nil
+}
+
+// Stream executes the request and returns the response with its body unread, for large
+// downloads and event feeds. The call runs through the same middleware, circuit breaker
+// and retries as Do, but is not cached, coalesced or hedged, and is only retried until the
+// response headers arrive. RequestTimeout bounds each attempt until the headers arrive, and
+// the client Timeout does not apply, so long downloads are not cut off. WithTimeout bounds
+// the whole call, including reading the body.
+// Responses with a non-2xx status are returned as errors, mapped like Response.Decode.
+// The call holds its bulkhead slot, and stays in flight on its load-balanced endpoint,
+// until the body is closed.
+func (r *Request) Stream() (*StreamResponse, error) {
+	req, err := r.build()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := r.callOptions()
+	opts.stream = true
+	resp, err := r.client.do(r.ctx, req, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.stream == nil {
+		if !resp.IsSuccess() {
+			return nil, MapHTTPStatus(resp.StatusCode, resp.Body)
+		}
+		// A middleware returned a buffered response.
+		return &StreamResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    resp.Headers,
+			Body:       io.NopCloser(bytes.NewReader(resp.Body)),
+		}, nil
+	}
+
+	return &StreamResponse{
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Headers,
+		Body:       resp.stream,
+	}, nil
+}
+
+// NDJSONDecoder decodes a stream of newline-delimited JSON values.
+type NDJSONDecoder struct {
+	r    *bufio.Reader
+	line int
+}
+
+// NewNDJSONDecoder creates an NDJSONDecoder reading from r.
+func NewNDJSONDecoder(r io.Reader) *NDJSONDecoder {
+	return &NDJSONDecoder{r: bufio.NewReader(r)}
+}
+
+// Decode decodes the next value into v. Blank lines are skipped.
+// It returns io.EOF at the end of the stream.
+func (d *NDJSONDecoder) Decode(v any) error {
+	for {
+		line, err := d.r.ReadBytes('\n')
+		if len(line) > 0 {
+			d.line++
+		}
+
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			if jsonErr := json.Unmarshal(line, v); jsonErr != nil {
+				return ErrBadGatewayWrap(fmt.Sprintf("invalid JSON on line %d", d.line), jsonErr)
+			}
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
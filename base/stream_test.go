@@ -0,0 +1,254 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Dorico-Dynamics/txova-go-core/errors"
+)
+
+func TestNDJSONDecoder(t *testing.T) {
+	type event struct {
+		ID int `json:"id"`
+	}
+
+	t.Run("decodes values and skips blank lines", func(t *testing.T) {
+		dec := NewNDJSONDecoder(strings.NewReader("{\"id\":1}\n\n  \n{\"id\":2}\r\n{\"id\":3}"))
+
+		var ids []int
+		for {
+			var ev event
+			err := dec.Decode(&ev)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			ids = append(ids, ev.ID)
+		}
+
+		if len(ids) != 3 || ids[0] != 1 || ids[1] != 2 || ids[2] != 3 {
+			t.Errorf("expected ids 1, 2 and 3, got %v", ids)
+		}
+	})
+
+	t.Run("reports the line of invalid JSON", func(t *testing.T) {
+		dec := NewNDJSONDecoder(strings.NewReader("{\"id\":1}\nnot json\n"))
+
+		var ev event
+		if err := dec.Decode(&ev); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		err := dec.Decode(&ev)
+		if !IsBadGateway(err) || !strings.Contains(err.Error(), "line 2") {
+			t.Errorf("expected bad gateway error on line 2, got %v", err)
+		}
+	})
+}
+
+func TestRequestStream(t *testing.T) {
+	t.Run("streams the body after the headers", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			for i := 1; i <= 3; i++ {
+				_, _ = fmt.Fprintf(w, "{\"id\":%d}\n", i)
+				w.(http.Flusher).Flush()
+				// The body takes longer than RequestTimeout, which only bounds the headers,
+				// and than the client Timeout, which does not apply to streamed calls.
+				time.Sleep(40 * time.Millisecond)
+			}
+		}))
+		defer server.Close()
+
+		client, err := NewClient(&Config{
+			BaseURL:        server.URL,
+			Timeout:        60 * time.Millisecond,
+			RequestTimeout: 50 * time.Millisecond,
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		resp, err := client.Get(context.Background(), "/exports/rides").Stream()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer resp.Close()
+
+		if resp.Header("Content-Type") != "application/x-ndjson" {
+			t.Errorf("unexpected content type: %s", resp.Header("Content-Type"))
+		}
+
+		dec := resp.NDJSON()
+		count := 0
+		for {
+			var ev struct {
+				ID int `json:"id"`
+			}
+			err := dec.Decode(&ev)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			count++
+		}
+		if count != 3 {
+			t.Errorf("expected 3 events, got %d", count)
+		}
+	})
+
+	t.Run("bounds the body by WithTimeout", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}))
+		defer server.Close()
+
+		client, err := NewClient(&Config{BaseURL: server.URL}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		resp, err := client.Get(context.Background(), "/exports/rides").WithTimeout(30 * time.Millisecond).Stream()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer resp.Close()
+
+		start := time.Now()
+		if _, err := io.ReadAll(resp.Body); err == nil {
+			t.Error("expected the read to fail when the timeout ends")
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("expected the timeout to end the read, took %v", elapsed)
+		}
+	})
+
+	t.Run("retries before the first byte", func(t *testing.T) {
+		var hits atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if hits.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = io.WriteString(w, "receipt")
+		}))
+		defer server.Close()
+
+		client, err := NewClient(&Config{
+			BaseURL: server.URL,
+			Retry:   RetryConfig{MaxRetries: 1, InitialWait: time.Millisecond, MaxWait: time.Millisecond, Multiplier: 1.0},
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		resp, err := client.Get(context.Background(), "/receipts/r1.pdf").Stream()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer resp.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(body) != "receipt" {
+			t.Errorf("unexpected body: %s", body)
+		}
+		if got := hits.Load(); got != 2 {
+			t.Errorf("expected 2 calls, got %d", got)
+		}
+	})
+
+	t.Run("times out waiting for the headers", func(t *testing.T) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}))
+		defer server.Close()
+		defer close(release)
+
+		client, err := NewClient(&Config{
+			BaseURL:        server.URL,
+			RequestTimeout: 20 * time.Millisecond,
+			Retry:          RetryConfig{MaxRetries: 1, InitialWait: time.Millisecond, MaxWait: time.Millisecond, Multiplier: 1.0},
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		_, err = client.Get(context.Background(), "/exports/rides").Stream()
+		if err == nil || !strings.Contains(err.Error(), "response headers not received") {
+			t.Errorf("expected header timeout error, got %v", err)
+		}
+	})
+
+	t.Run("maps error statuses", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client, err := NewClient(&Config{BaseURL: server.URL}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		_, err = client.Get(context.Background(), "/receipts/missing.pdf").Stream()
+		appErr := errors.AsAppError(err)
+		if appErr == nil {
+			t.Fatalf("expected AppError, got %v", err)
+		}
+		if appErr.Code() != errors.CodeNotFound {
+			t.Errorf("expected code NOT_FOUND, got %s", appErr.Code())
+		}
+	})
+
+	t.Run("bypasses the cache", func(t *testing.T) {
+		var hits atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits.Add(1)
+			w.Header().Set("Cache-Control", "max-age=60")
+			_, _ = io.WriteString(w, "export")
+		}))
+		defer server.Close()
+
+		client, err := NewClient(&Config{BaseURL: server.URL, Cache: &CacheConfig{}}, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		for i := 0; i < 2; i++ {
+			resp, err := client.Get(context.Background(), "/exports/rides").Stream()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			_ = resp.Close()
+			if string(body) != "export" {
+				t.Errorf("unexpected body: %s", body)
+			}
+		}
+		if got := hits.Load(); got != 2 {
+			t.Errorf("expected 2 calls, got %d", got)
+		}
+	})
+}
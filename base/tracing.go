@@ -0,0 +1,183 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope name used for spans created by base.Client.
+const tracerName = "github.com/Dorico-Dynamics/txova-go-clients/base"
+
+// Span attribute keys.
+const (
+	attrService      = attribute.Key("peer.service")
+	attrMethod       = attribute.Key("http.request.method")
+	attrURL          = attribute.Key("url.full")
+	attrURLTemplate  = attribute.Key("url.template")
+	attrStatusCode   = attribute.Key("http.response.status_code")
+	attrResendCount  = attribute.Key("http.request.resend_count")
+	attrCircuitState = attribute.Key("txova.circuit.state")
+	attrRetryWaitMS  = attribute.Key("txova.retry.wait_ms")
+	attrRetryAttempt = attribute.Key("txova.retry.attempt")
+	attrRetryReason  = attribute.Key("txova.retry.reason")
+)
+
+// Span naming.
+const (
+	spanPrefix     = "HTTP "
+	retryEventName = "retry"
+)
+
+// TracingConfig holds OpenTelemetry tracing configuration.
+type TracingConfig struct {
+	// TracerProvider creates the tracer used for call and attempt spans (required).
+	TracerProvider trace.TracerProvider
+
+	// Propagator injects trace context into outgoing requests (default: W3C traceparent/tracestate).
+	Propagator propagation.TextMapPropagator
+}
+
+// Validate validates the tracing configuration.
+func (c *TracingConfig) Validate() error {
+	if c.TracerProvider == nil {
+		return fmt.Errorf("tracer provider is required")
+	}
+	return nil
+}
+
+// tracing holds the tracer and propagator of a client.
+type tracing struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// newTracing creates the client tracing state, or nil if tracing is disabled.
+func newTracing(cfg *TracingConfig) *tracing {
+	if cfg == nil {
+		return nil
+	}
+
+	propagator := cfg.Propagator
+	if propagator == nil {
+		propagator = propagation.TraceContext{}
+	}
+
+	return &tracing{
+		tracer:     cfg.TracerProvider.Tracer(tracerName),
+		propagator: propagator,
+	}
+}
+
+// TracingMiddleware creates a parent span for every logical call.
+// The span is annotated with the service name, final status code and circuit state.
+// It has no effect if the client has no tracing configured.
+func TracingMiddleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *http.Request) (*Response, error) {
+			info := CallInfoFromContext(ctx)
+			if info == nil || info.client.tracing == nil {
+				return next(ctx, req)
+			}
+
+			// Only attempt spans are client spans, so each outgoing request is counted once.
+			ctx, span := info.client.tracing.tracer.Start(ctx, spanName(req, info),
+				trace.WithSpanKind(trace.SpanKindInternal),
+				trace.WithAttributes(spanAttributes(req, info)...),
+			)
+			defer span.End()
+
+			resp, err := next(ctx, req)
+
+			if cb := info.breaker; cb != nil {
+				span.SetAttributes(attrCircuitState.String(cb.State().String()))
+			}
+			endSpan(span, resp, err)
+
+			return resp, err
+		}
+	}
+}
+
+// TracingAttemptMiddleware creates a child span for every attempt and injects the
+// trace context into the outgoing request headers.
+// It has no effect if the client has no tracing configured.
+func TracingAttemptMiddleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *http.Request) (*Response, error) {
+			info := CallInfoFromContext(ctx)
+			if info == nil || info.client.tracing == nil {
+				return next(ctx, req)
+			}
+
+			ctx, span := info.client.tracing.tracer.Start(ctx, spanName(req, info)+" attempt",
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(append(spanAttributes(req, info), attrResendCount.Int(info.Attempt))...),
+			)
+			defer span.End()
+
+			info.client.tracing.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+			resp, err := next(ctx, req)
+			endSpan(span, resp, err)
+
+			return resp, err
+		}
+	}
+}
+
+// spanName returns the name of the call span: the method, followed by the path template
+// for requests built with path parameters.
+func spanName(req *http.Request, info *CallInfo) string {
+	if info.template {
+		return spanPrefix + req.Method + " " + info.Route
+	}
+	return spanPrefix + req.Method
+}
+
+// spanAttributes returns the attributes shared by call and attempt spans.
+func spanAttributes(req *http.Request, info *CallInfo) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attrService.String(info.Service),
+		attrMethod.String(req.Method),
+		attrURL.String(req.URL.String()),
+	}
+	if info.template {
+		attrs = append(attrs, attrURLTemplate.String(info.Route))
+	}
+	return attrs
+}
+
+// endSpan records the outcome of a call or attempt on the span.
+func endSpan(span trace.Span, resp *Response, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	span.SetAttributes(attrStatusCode.Int(resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+}
+
+// addRetryEvent records a retry on the call span in ctx, if any.
+func addRetryEvent(ctx context.Context, attempt int, wait time.Duration, reason error) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	span.AddEvent(retryEventName, trace.WithAttributes(
+		attrRetryAttempt.Int(attempt+1),
+		attrRetryWaitMS.Int64(wait.Milliseconds()),
+		attrRetryReason.String(reason.Error()),
+	))
+}
@@ -0,0 +1,242 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTracedClient(t *testing.T, serverURL string, exporter *tracetest.InMemoryExporter) *Client {
+	t.Helper()
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	client, err := NewClient(&Config{
+		BaseURL:        serverURL,
+		Timeout:        30 * time.Second,
+		RequestTimeout: 10 * time.Second,
+		Retry: RetryConfig{
+			MaxRetries:  2,
+			InitialWait: time.Millisecond,
+			MaxWait:     5 * time.Millisecond,
+			Multiplier:  1.0,
+		},
+		CircuitBreaker: DefaultCircuitBreakerConfig("test"),
+		Tracing:        &TracingConfig{TracerProvider: provider},
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+func findSpans(spans tracetest.SpanStubs, name string) []tracetest.SpanStub {
+	var found []tracetest.SpanStub
+	for _, s := range spans {
+		if s.Name == name {
+			found = append(found, s)
+		}
+	}
+	return found
+}
+
+func spanAttr(s tracetest.SpanStub, key string) (string, bool) {
+	for _, kv := range s.Attributes {
+		if string(kv.Key) == key {
+			return kv.Value.Emit(), true
+		}
+	}
+	return "", false
+}
+
+func TestTracingConfigValidate(t *testing.T) {
+	cfg := &TracingConfig{}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for missing tracer provider")
+	}
+}
+
+func TestClientTracing(t *testing.T) {
+	t.Run("creates call span with attempt child spans", func(t *testing.T) {
+		var requests int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&requests, 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		exporter := tracetest.NewInMemoryExporter()
+		client := newTracedClient(t, server.URL, exporter)
+
+		if _, err := client.Get(context.Background(), "/test").Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		spans := exporter.GetSpans()
+		calls := findSpans(spans, "HTTP GET")
+		attempts := findSpans(spans, "HTTP GET attempt")
+		if len(calls) != 1 {
+			t.Fatalf("expected 1 call span, got %d", len(calls))
+		}
+		if len(attempts) != 2 {
+			t.Fatalf("expected 2 attempt spans, got %d", len(attempts))
+		}
+
+		call := calls[0]
+		if call.SpanKind != trace.SpanKindInternal {
+			t.Errorf("expected internal span kind for the call, got %s", call.SpanKind)
+		}
+		if v, _ := spanAttr(call, "http.response.status_code"); v != "200" {
+			t.Errorf("expected status code 200 on call span, got %q", v)
+		}
+		if v, _ := spanAttr(call, "txova.circuit.state"); v != "closed" {
+			t.Errorf("expected circuit state closed, got %q", v)
+		}
+		if v, ok := spanAttr(call, "peer.service"); !ok || !strings.HasPrefix(v, "127.0.0.1") {
+			t.Errorf("expected peer.service to be the server host, got %q", v)
+		}
+
+		if len(call.Events) != 1 || call.Events[0].Name != "retry" {
+			t.Fatalf("expected 1 retry event on call span, got %+v", call.Events)
+		}
+
+		for i, attempt := range attempts {
+			if attempt.Parent.SpanID() != call.SpanContext.SpanID() {
+				t.Errorf("attempt %d is not a child of the call span", i)
+			}
+			if attempt.SpanKind != trace.SpanKindClient {
+				t.Errorf("expected client span kind for attempt %d, got %s", i, attempt.SpanKind)
+			}
+			if v, _ := spanAttr(attempt, "http.request.resend_count"); v != []string{"0", "1"}[i] {
+				t.Errorf("expected resend count %d, got %q", i, v)
+			}
+		}
+		if v, _ := spanAttr(attempts[0], "http.response.status_code"); v != "503" {
+			t.Errorf("expected first attempt status 503, got %q", v)
+		}
+	})
+
+	t.Run("propagates W3C trace context", func(t *testing.T) {
+		var traceparent string
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			traceparent = r.Header.Get("traceparent")
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		exporter := tracetest.NewInMemoryExporter()
+		client := newTracedClient(t, server.URL, exporter)
+
+		if _, err := client.Get(context.Background(), "/test").Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		attempts := findSpans(exporter.GetSpans(), "HTTP GET attempt")
+		if len(attempts) != 1 {
+			t.Fatalf("expected 1 attempt span, got %d", len(attempts))
+		}
+
+		sc := attempts[0].SpanContext
+		expected := "00-" + sc.TraceID().String() + "-" + sc.SpanID().String() + "-01"
+		if traceparent != expected {
+			t.Errorf("expected traceparent %q, got %q", expected, traceparent)
+		}
+	})
+
+	t.Run("marks rejected calls with open circuit", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		exporter := tracetest.NewInMemoryExporter()
+		client := newTracedClient(t, server.URL, exporter)
+		for i := 0; i < 5; i++ {
+			client.circuitBreaker.RecordFailure()
+		}
+
+		_, err := client.Get(context.Background(), "/test").Do()
+		if !IsCircuitOpen(err) {
+			t.Fatalf("expected circuit open error, got %v", err)
+		}
+
+		calls := findSpans(exporter.GetSpans(), "HTTP GET")
+		if len(calls) != 1 {
+			t.Fatalf("expected 1 call span, got %d", len(calls))
+		}
+		if v, _ := spanAttr(calls[0], "txova.circuit.state"); v != "open" {
+			t.Errorf("expected circuit state open, got %q", v)
+		}
+		if calls[0].Status.Code.String() != "Error" {
+			t.Errorf("expected error status, got %s", calls[0].Status.Code)
+		}
+		if len(findSpans(exporter.GetSpans(), "HTTP GET attempt")) != 0 {
+			t.Error("expected no attempt spans for rejected call")
+		}
+	})
+
+	t.Run("names spans by path template", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		exporter := tracetest.NewInMemoryExporter()
+		client := newTracedClient(t, server.URL, exporter)
+
+		if _, err := client.Get(context.Background(), "/rides/{id}", Param("id", "r1")).Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		calls := findSpans(exporter.GetSpans(), "HTTP GET /rides/{id}")
+		if len(calls) != 1 {
+			t.Fatalf("expected 1 call span, got %d", len(calls))
+		}
+		if v, _ := spanAttr(calls[0], "url.template"); v != "/rides/{id}" {
+			t.Errorf("expected url.template /rides/{id}, got %q", v)
+		}
+		if v, _ := spanAttr(calls[0], "url.full"); !strings.HasSuffix(v, "/rides/r1") {
+			t.Errorf("expected expanded url.full, got %q", v)
+		}
+		if len(findSpans(exporter.GetSpans(), "HTTP GET /rides/{id} attempt")) != 1 {
+			t.Error("expected 1 attempt span named by template")
+		}
+	})
+
+	t.Run("does nothing without tracing config", func(t *testing.T) {
+		var traceparent string
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			traceparent = r.Header.Get("traceparent")
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client, _ := NewClient(&Config{
+			BaseURL:        server.URL,
+			Timeout:        30 * time.Second,
+			RequestTimeout: 10 * time.Second,
+		}, nil)
+
+		if _, err := client.Get(context.Background(), "/test").Do(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if traceparent != "" {
+			t.Errorf("expected no traceparent header, got %q", traceparent)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// RequestOption customizes a request sent by the typed request helpers.
+type RequestOption func(*Request) *Request
+
+// Query returns a RequestOption that adds a query parameter.
+func Query(key, value string) RequestOption {
+	return func(r *Request) *Request {
+		return r.WithQuery(key, value)
+	}
+}
+
+// Header returns a RequestOption that sets a header.
+func Header(key, value string) RequestOption {
+	return func(r *Request) *Request {
+		return r.WithHeader(key, value)
+	}
+}
+
+// IdempotencyKey returns a RequestOption that sets the idempotency key.
+func IdempotencyKey(key string) RequestOption {
+	return func(r *Request) *Request {
+		return r.WithIdempotencyKey(key)
+	}
+}
+
+// Idempotent returns a RequestOption that marks the request as safe to send more than once.
+func Idempotent() RequestOption {
+	return func(r *Request) *Request {
+		return r.WithIdempotent()
+	}
+}
+
+// Hedged returns a RequestOption that enables hedging for the request.
+func Hedged() RequestOption {
+	return func(r *Request) *Request {
+		return r.WithHedging()
+	}
+}
+
+// GetJSON sends a GET request and decodes the JSON response into a new T.
+// Responses with a non-2xx status are returned as errors, mapped like Response.Decode.
+func GetJSON[T any](ctx context.Context, c *Client, path string, opts ...RequestOption) (*T, error) {
+	return decodeJSON[T](c.Get(ctx, path), opts)
+}
+
+// PostJSON sends body as JSON in a POST request and decodes the JSON response into a new Resp.
+// The body type is inferred, so only Resp needs to be given: base.PostJSON[Refund](ctx, c, path, req).
+func PostJSON[Resp, Req any](ctx context.Context, c *Client, path string, body Req, opts ...RequestOption) (*Resp, error) {
+	return decodeJSON[Resp](c.Post(ctx, path, body), opts)
+}
+
+// PutJSON sends body as JSON in a PUT request and decodes the JSON response into a new Resp.
+func PutJSON[Resp, Req any](ctx context.Context, c *Client, path string, body Req, opts ...RequestOption) (*Resp, error) {
+	return decodeJSON[Resp](c.Put(ctx, path, body), opts)
+}
+
+// Exec sends a request with an optional JSON body and discards the response body.
+// Responses with a non-2xx status are returned as errors.
+func Exec(ctx context.Context, c *Client, method, path string, body any, opts ...RequestOption) error {
+	resp, err := applyOptions(c.newRequest(ctx, method, path, body), opts).Do()
+	if err != nil {
+		return err
+	}
+
+	if !resp.IsSuccess() {
+		return resp.DecodeError()
+	}
+
+	return nil
+}
+
+// applyOptions applies request options in order.
+func applyOptions(r *Request, opts []RequestOption) *Request {
+	for _, opt := range opts {
+		r = opt(r)
+	}
+	return r
+}
+
+// decodeJSON sends the request and decodes the JSON response, unwrapped from the client's
+// response envelope, into a new T.
+func decodeJSON[T any](r *Request, opts []RequestOption) (*T, error) {
+	resp, err := applyOptions(r, opts).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.IsSuccess() {
+		return nil, resp.DecodeError()
+	}
+
+	body, err := r.client.unwrapEnvelope(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var dest T
+	if len(body) == 0 {
+		return &dest, nil
+	}
+	if err := json.Unmarshal(body, &dest); err != nil {
+		return nil, ErrBadGatewayWrap("failed to decode response", err)
+	}
+
+	return &dest, nil
+}
+
+// unwrapEnvelope returns the value of the response envelope field, or the body as is if
+// the client has no response envelope.
+func (c *Client) unwrapEnvelope(body []byte) ([]byte, error) {
+	if c.envelope == "" || len(body) == 0 {
+		return body, nil
+	}
+
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, ErrBadGatewayWrap("failed to decode response", err)
+	}
+
+	data, ok := envelope[c.envelope]
+	if !ok {
+		return nil, ErrBadGateway(fmt.Sprintf("response has no %q field", c.envelope))
+	}
+	return data, nil
+}
@@ -0,0 +1,157 @@
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Dorico-Dynamics/txova-go-core/errors"
+)
+
+type typedRide struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+type typedCancel struct {
+	Reason string `json:"reason"`
+}
+
+func TestTypedHelpers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rides/r1":
+			_, _ = io.WriteString(w, `{"id":"r1","status":"accepted"}`)
+		case r.URL.RawPath == "/rides/r%2F1":
+			_, _ = io.WriteString(w, `{"id":"r/1","status":"accepted"}`)
+		case r.URL.Path == "/rides/active":
+			if r.URL.Query().Get("user_id") != "u1" {
+				t.Errorf("expected user_id query, got %s", r.URL.RawQuery)
+			}
+			_, _ = io.WriteString(w, `{"id":"r2","status":"started"}`)
+		case r.URL.Path == "/rides/r1/cancel":
+			var body typedCancel
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Reason != "rider" {
+				t.Errorf("unexpected body: %+v, %v", body, err)
+			}
+			if r.Header.Get(DefaultIdempotencyHeader) != "cancel-r1" {
+				t.Errorf("expected idempotency key, got %q", r.Header.Get(DefaultIdempotencyHeader))
+			}
+			_, _ = io.WriteString(w, `{"id":"r1","status":"cancelled"}`)
+		case r.URL.Path == "/rides/r1/empty":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"error":{"code":"NOT_FOUND","message":"ride not found"}}`)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{BaseURL: server.URL, Retry: RetryConfig{MaxRetries: 0, Multiplier: 1.0}}, nil)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	t.Run("GetJSON decodes the response", func(t *testing.T) {
+		ride, err := GetJSON[typedRide](ctx, client, "/rides/{id}", Param("id", "r1"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ride.ID != "r1" || ride.Status != "accepted" {
+			t.Errorf("unexpected ride: %+v", ride)
+		}
+	})
+
+	t.Run("GetJSON escapes path parameters", func(t *testing.T) {
+		ride, err := GetJSON[typedRide](ctx, client, "/rides/{id}", Param("id", "r/1"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ride.ID != "r/1" {
+			t.Errorf("unexpected ride: %+v", ride)
+		}
+	})
+
+	t.Run("GetJSON applies options", func(t *testing.T) {
+		ride, err := GetJSON[typedRide](ctx, client, "/rides/active", Query("user_id", "u1"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ride.ID != "r2" {
+			t.Errorf("unexpected ride: %+v", ride)
+		}
+	})
+
+	t.Run("GetJSON maps error statuses", func(t *testing.T) {
+		_, err := GetJSON[typedRide](ctx, client, "/rides/missing")
+		appErr := errors.AsAppError(err)
+		if appErr == nil {
+			t.Fatalf("expected AppError, got %v", err)
+		}
+		if appErr.Code() != errors.CodeNotFound {
+			t.Errorf("expected code NOT_FOUND, got %s", appErr.Code())
+		}
+	})
+
+	t.Run("GetJSON returns the zero value for an empty body", func(t *testing.T) {
+		ride, err := GetJSON[typedRide](ctx, client, "/rides/r1/empty")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *ride != (typedRide{}) {
+			t.Errorf("expected zero value, got %+v", ride)
+		}
+	})
+
+	t.Run("PostJSON sends the body", func(t *testing.T) {
+		ride, err := PostJSON[typedRide](ctx, client, "/rides/r1/cancel", typedCancel{Reason: "rider"},
+			IdempotencyKey("cancel-r1"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ride.Status != "cancelled" {
+			t.Errorf("unexpected ride: %+v", ride)
+		}
+	})
+
+	t.Run("Exec checks the status", func(t *testing.T) {
+		if err := Exec(ctx, client, http.MethodPost, "/rides/r1/cancel", typedCancel{Reason: "rider"}, IdempotencyKey("cancel-r1")); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if err := Exec(ctx, client, http.MethodDelete, "/rides/missing", nil); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestTypedHelpersEnvelope(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rides/r1" {
+			_, _ = io.WriteString(w, `{"data":{"id":"r1","status":"accepted"},"meta":{}}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"id":"r2"}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{BaseURL: server.URL, ResponseEnvelope: "data"}, nil)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ride, err := GetJSON[typedRide](context.Background(), client, "/rides/r1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ride.ID != "r1" || ride.Status != "accepted" {
+		t.Errorf("unexpected ride: %+v", ride)
+	}
+
+	if _, err := GetJSON[typedRide](context.Background(), client, "/rides/r2"); !IsBadGateway(err) {
+		t.Errorf("expected bad gateway error for a missing envelope, got %v", err)
+	}
+}
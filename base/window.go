@@ -0,0 +1,174 @@
+package base
+
+import "time"
+
+// Number of buckets a time window is divided into.
+const timeWindowBuckets = 10
+
+// windowCounts holds the call outcome counts of a sliding window.
+type windowCounts struct {
+	requests int
+	failures int
+	slow     int
+}
+
+// add adds the given outcome to the counts.
+func (c *windowCounts) add(failure, slow bool) {
+	c.requests++
+	if failure {
+		c.failures++
+	}
+	if slow {
+		c.slow++
+	}
+}
+
+// failureRate returns the fraction of failed calls.
+func (c windowCounts) failureRate() float64 {
+	if c.requests == 0 {
+		return 0
+	}
+	return float64(c.failures) / float64(c.requests)
+}
+
+// slowCallRate returns the fraction of slow calls.
+func (c windowCounts) slowCallRate() float64 {
+	if c.requests == 0 {
+		return 0
+	}
+	return float64(c.slow) / float64(c.requests)
+}
+
+// slidingWindow aggregates call outcomes for a failure-rate circuit breaker.
+// Implementations are not safe for concurrent use.
+type slidingWindow interface {
+	record(failure, slow bool, now time.Time)
+	counts(now time.Time) windowCounts
+	reset()
+}
+
+// newSlidingWindow returns the window for the configured mode, or nil in consecutive mode.
+func newSlidingWindow(cfg *CircuitBreakerConfig) slidingWindow {
+	switch cfg.Mode {
+	case CircuitModeCountWindow:
+		size := cfg.WindowSize
+		if size <= 0 {
+			size = defaultWindowSize
+		}
+		return newCountWindow(size)
+
+	case CircuitModeTimeWindow:
+		d := cfg.WindowDuration
+		if d <= 0 {
+			d = defaultWindowDuration
+		}
+		return newTimeWindow(d)
+
+	default:
+		return nil
+	}
+}
+
+// windowOutcome is a single call outcome in a count window.
+type windowOutcome struct {
+	failure bool
+	slow    bool
+}
+
+// countWindow keeps the outcomes of the last N calls.
+type countWindow struct {
+	outcomes []windowOutcome
+	next     int
+	filled   int
+	totals   windowCounts
+}
+
+// newCountWindow creates a window over the last size calls.
+func newCountWindow(size int) *countWindow {
+	return &countWindow{outcomes: make([]windowOutcome, size)}
+}
+
+func (w *countWindow) record(failure, slow bool, _ time.Time) {
+	if w.filled == len(w.outcomes) {
+		// Evict the oldest outcome.
+		old := w.outcomes[w.next]
+		w.totals.requests--
+		if old.failure {
+			w.totals.failures--
+		}
+		if old.slow {
+			w.totals.slow--
+		}
+	} else {
+		w.filled++
+	}
+
+	w.outcomes[w.next] = windowOutcome{failure: failure, slow: slow}
+	w.next = (w.next + 1) % len(w.outcomes)
+	w.totals.add(failure, slow)
+}
+
+func (w *countWindow) counts(time.Time) windowCounts {
+	return w.totals
+}
+
+func (w *countWindow) reset() {
+	clear(w.outcomes)
+	w.next = 0
+	w.filled = 0
+	w.totals = windowCounts{}
+}
+
+// timeBucket holds the outcome counts of one slice of a time window.
+type timeBucket struct {
+	epoch int64
+	windowCounts
+}
+
+// timeWindow keeps the outcomes of calls made within a rolling duration.
+// The duration is divided into buckets which expire as a whole.
+type timeWindow struct {
+	width   time.Duration
+	buckets []timeBucket
+}
+
+// newTimeWindow creates a window over the given duration.
+func newTimeWindow(d time.Duration) *timeWindow {
+	width := d / timeWindowBuckets
+	if width <= 0 {
+		width = 1
+	}
+	return &timeWindow{
+		width:   width,
+		buckets: make([]timeBucket, timeWindowBuckets),
+	}
+}
+
+func (w *timeWindow) record(failure, slow bool, now time.Time) {
+	epoch := now.UnixNano() / int64(w.width)
+	b := &w.buckets[epoch%int64(len(w.buckets))]
+	if b.epoch != epoch {
+		*b = timeBucket{epoch: epoch}
+	}
+	b.add(failure, slow)
+}
+
+func (w *timeWindow) counts(now time.Time) windowCounts {
+	epoch := now.UnixNano() / int64(w.width)
+	oldest := epoch - int64(len(w.buckets)) + 1
+
+	var totals windowCounts
+	for _, b := range w.buckets {
+		if b.epoch < oldest || b.epoch > epoch {
+			continue
+		}
+		totals.requests += b.requests
+		totals.failures += b.failures
+		totals.slow += b.slow
+	}
+	return totals
+}
+
+func (w *timeWindow) reset() {
+	clear(w.buckets)
+}
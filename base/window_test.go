@@ -0,0 +1,94 @@
+package base
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewSlidingWindow(t *testing.T) {
+	if w := newSlidingWindow(&CircuitBreakerConfig{}); w != nil {
+		t.Errorf("expected nil window in consecutive mode, got %T", w)
+	}
+
+	cw, ok := newSlidingWindow(&CircuitBreakerConfig{Mode: CircuitModeCountWindow}).(*countWindow)
+	if !ok || len(cw.outcomes) != defaultWindowSize {
+		t.Errorf("expected count window of size %d", defaultWindowSize)
+	}
+
+	tw, ok := newSlidingWindow(&CircuitBreakerConfig{Mode: CircuitModeTimeWindow}).(*timeWindow)
+	if !ok || tw.width != defaultWindowDuration/timeWindowBuckets {
+		t.Errorf("expected time window of %v", defaultWindowDuration)
+	}
+}
+
+func TestCountWindow(t *testing.T) {
+	w := newCountWindow(3)
+	now := time.Now()
+
+	w.record(true, false, now)
+	w.record(false, true, now)
+	w.record(false, false, now)
+
+	counts := w.counts(now)
+	if counts.requests != 3 || counts.failures != 1 || counts.slow != 1 {
+		t.Errorf("unexpected counts: %+v", counts)
+	}
+
+	// The oldest failure is evicted.
+	w.record(false, false, now)
+
+	counts = w.counts(now)
+	if counts.requests != 3 || counts.failures != 0 || counts.slow != 1 {
+		t.Errorf("unexpected counts after eviction: %+v", counts)
+	}
+
+	w.reset()
+	if counts := w.counts(now); counts != (windowCounts{}) {
+		t.Errorf("expected empty counts after reset, got %+v", counts)
+	}
+}
+
+func TestTimeWindow(t *testing.T) {
+	w := newTimeWindow(10 * time.Second)
+	start := time.Unix(1000, 0)
+
+	w.record(true, false, start)
+	w.record(false, true, start.Add(3*time.Second))
+	w.record(false, false, start.Add(9*time.Second))
+
+	counts := w.counts(start.Add(9 * time.Second))
+	if counts.requests != 3 || counts.failures != 1 || counts.slow != 1 {
+		t.Errorf("unexpected counts: %+v", counts)
+	}
+
+	// The first bucket has expired.
+	counts = w.counts(start.Add(10 * time.Second))
+	if counts.requests != 2 || counts.failures != 0 {
+		t.Errorf("unexpected counts after expiry: %+v", counts)
+	}
+
+	// A recycled bucket drops its previous outcomes.
+	w.record(false, false, start.Add(13*time.Second))
+	counts = w.counts(start.Add(13 * time.Second))
+	if counts.requests != 2 || counts.slow != 0 {
+		t.Errorf("unexpected counts after recycling: %+v", counts)
+	}
+
+	w.reset()
+	if counts := w.counts(start.Add(13 * time.Second)); counts.requests != 0 {
+		t.Errorf("expected empty counts after reset, got %+v", counts)
+	}
+}
+
+func TestWindowCountsRates(t *testing.T) {
+	var c windowCounts
+	if c.failureRate() != 0 || c.slowCallRate() != 0 {
+		t.Error("expected zero rates for empty counts")
+	}
+
+	c.add(true, true)
+	c.add(false, false)
+	if c.failureRate() != 0.5 || c.slowCallRate() != 0.5 {
+		t.Errorf("expected rates of 0.5, got %v and %v", c.failureRate(), c.slowCallRate())
+	}
+}
@@ -6,10 +6,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"time"
 
+	"github.com/Dorico-Dynamics/txova-go-clients/base"
 	"github.com/Dorico-Dynamics/txova-go-core/logging"
 	"github.com/Dorico-Dynamics/txova-go-types/contact"
 )
@@ -20,6 +20,7 @@ const sendGridAPIURL = "https://api.sendgrid.com/v3/mail/send"
 // Client is the Email client for SendGrid.
 type Client struct {
 	httpClient *http.Client
+	bodyLimit  int64
 	apiKey     string
 	fromEmail  string
 	fromName   string
@@ -39,6 +40,9 @@ type Config struct {
 
 	// Timeout is the request timeout (default: 30s).
 	Timeout time.Duration
+
+	// MaxResponseSize is the maximum size of a response body in bytes (default: 10 MiB).
+	MaxResponseSize int64
 }
 
 // NewClient creates a new Email client.
@@ -58,8 +62,14 @@ func NewClient(cfg *Config, logger *logging.Logger) (*Client, error) {
 		timeout = 30 * time.Second
 	}
 
+	bodyLimit, err := base.ResponseLimit(cfg.MaxResponseSize)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Client{
 		httpClient: &http.Client{Timeout: timeout},
+		bodyLimit:  bodyLimit,
 		apiKey:     cfg.APIKey,
 		fromEmail:  cfg.FromEmail,
 		fromName:   cfg.FromName,
@@ -242,7 +252,7 @@ func (c *Client) sendRequest(ctx context.Context, sgReq sendGridRequest) error {
 
 	// SendGrid returns 202 Accepted for successful sends
 	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
-		respBody, err := io.ReadAll(resp.Body)
+		respBody, err := base.ReadBody(resp.Body, c.bodyLimit)
 		if err != nil {
 			return fmt.Errorf("SendGrid API error: status %d (failed to read body: %w)", resp.StatusCode, err)
 		}
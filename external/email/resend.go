@@ -5,10 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"time"
 
+	"github.com/Dorico-Dynamics/txova-go-clients/base"
 	"github.com/Dorico-Dynamics/txova-go-core/logging"
 	"github.com/Dorico-Dynamics/txova-go-types/contact"
 )
@@ -20,6 +20,7 @@ const resendBaseURL = "https://api.resend.com"
 // Resend is a modern email API designed for developers with excellent deliverability.
 type ResendClient struct {
 	httpClient *http.Client
+	bodyLimit  int64
 	baseURL    string
 	apiKey     string
 	fromEmail  string
@@ -40,6 +41,9 @@ type ResendConfig struct {
 
 	// Timeout is the request timeout (default: 30s).
 	Timeout time.Duration
+
+	// MaxResponseSize is the maximum size of a response body in bytes (default: 10 MiB).
+	MaxResponseSize int64
 }
 
 // NewResendClient creates a new Resend email client.
@@ -59,8 +63,14 @@ func NewResendClient(cfg *ResendConfig, logger *logging.Logger) (*ResendClient,
 		timeout = 30 * time.Second
 	}
 
+	bodyLimit, err := base.ResponseLimit(cfg.MaxResponseSize)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ResendClient{
 		httpClient: &http.Client{Timeout: timeout},
+		bodyLimit:  bodyLimit,
 		baseURL:    resendBaseURL,
 		apiKey:     cfg.APIKey,
 		fromEmail:  cfg.FromEmail,
@@ -249,7 +259,7 @@ func (c *ResendClient) sendEmail(ctx context.Context, req resendEmailRequest) (*
 	}
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	respBody, err := base.ReadBody(resp.Body, c.bodyLimit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
@@ -69,6 +69,15 @@ func TestNewResendClient(t *testing.T) {
 			},
 			wantErr: "from email is required",
 		},
+		{
+			name: "returns error with negative max response size",
+			cfg: &ResendConfig{
+				APIKey:          "re_test_key",
+				FromEmail:       "noreply@example.com",
+				MaxResponseSize: -1,
+			},
+			wantErr: "max response size cannot be negative",
+		},
 	}
 
 	for _, tt := range tests {
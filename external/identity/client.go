@@ -15,6 +15,8 @@ import (
 	"time"
 
 	"github.com/Dorico-Dynamics/txova-go-core/logging"
+
+	"github.com/Dorico-Dynamics/txova-go-clients/base"
 )
 
 // API environments.
@@ -26,6 +28,7 @@ const (
 // Client is the Smile Identity client.
 type Client struct {
 	httpClient *http.Client
+	bodyLimit  int64
 	baseURL    string
 	partnerID  string
 	apiKey     string
@@ -45,6 +48,12 @@ type Config struct {
 
 	// Timeout is the request timeout (default: 60s).
 	Timeout time.Duration
+
+	// MaxResponseSize is the maximum size of a response body in bytes (default: 10 MiB).
+	MaxResponseSize int64
+
+	// RateLimit limits the rate of requests to the provider. Disabled if nil.
+	RateLimit *base.RateLimitConfig
 }
 
 // NewClient creates a new Smile Identity client.
@@ -64,13 +73,28 @@ func NewClient(cfg *Config, logger *logging.Logger) (*Client, error) {
 		timeout = 60 * time.Second
 	}
 
+	bodyLimit, err := base.ResponseLimit(cfg.MaxResponseSize)
+	if err != nil {
+		return nil, err
+	}
+
 	baseURL := ProductionBaseURL
 	if cfg.Sandbox {
 		baseURL = SandboxBaseURL
 	}
 
+	httpClient := &http.Client{Timeout: timeout}
+	if cfg.RateLimit != nil {
+		transport, err := base.NewRateLimitTransport(cfg.RateLimit, "smileidentity", nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit config: %w", err)
+		}
+		httpClient.Transport = transport
+	}
+
 	return &Client{
-		httpClient: &http.Client{Timeout: timeout},
+		httpClient: httpClient,
+		bodyLimit:  bodyLimit,
 		baseURL:    baseURL,
 		partnerID:  cfg.PartnerID,
 		apiKey:     cfg.APIKey,
@@ -463,7 +487,7 @@ func (c *Client) doRequest(ctx context.Context, path string, body, result any) e
 	}
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	respBody, err := base.ReadBody(resp.Body, c.bodyLimit)
 	if err != nil {
 		return fmt.Errorf("failed to read response: %w", err)
 	}
@@ -12,6 +12,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/Dorico-Dynamics/txova-go-core/logging"
+
+	"github.com/Dorico-Dynamics/txova-go-clients/base"
 )
 
 func newTestClient(t *testing.T) (*Client, *logging.Logger) {
@@ -68,6 +70,33 @@ func TestNewClient(t *testing.T) {
 			},
 			wantErr: "API key is required",
 		},
+		{
+			name: "creates client with rate limit",
+			cfg: &Config{
+				PartnerID: "partner123",
+				APIKey:    "apikey123",
+				RateLimit: &base.RateLimitConfig{Rate: 5},
+			},
+			wantErr: "",
+		},
+		{
+			name: "returns error with invalid rate limit",
+			cfg: &Config{
+				PartnerID: "partner123",
+				APIKey:    "apikey123",
+				RateLimit: &base.RateLimitConfig{},
+			},
+			wantErr: "invalid rate limit config",
+		},
+		{
+			name: "returns error with negative max response size",
+			cfg: &Config{
+				PartnerID:       "partner123",
+				APIKey:          "apikey123",
+				MaxResponseSize: -1,
+			},
+			wantErr: "max response size cannot be negative",
+		},
 	}
 
 	for _, tt := range tests {
@@ -25,6 +25,8 @@ import (
 	"github.com/Dorico-Dynamics/txova-go-types/enums"
 	"github.com/Dorico-Dynamics/txova-go-types/ids"
 	"github.com/Dorico-Dynamics/txova-go-types/money"
+
+	"github.com/Dorico-Dynamics/txova-go-clients/base"
 )
 
 // M-Pesa API environments.
@@ -42,6 +44,7 @@ type EventPublisher interface {
 // Client is the M-Pesa client.
 type Client struct {
 	httpClient             *http.Client
+	bodyLimit              int64
 	baseURL                string
 	apiKey                 string
 	publicKey              string
@@ -73,6 +76,12 @@ type Config struct {
 	// Timeout is the request timeout (default: 60s for payment operations).
 	Timeout time.Duration
 
+	// MaxResponseSize is the maximum size of a response body in bytes (default: 10 MiB).
+	MaxResponseSize int64
+
+	// RateLimit limits the rate of requests to the provider. Disabled if nil.
+	RateLimit *base.RateLimitConfig
+
 	// Producer is the Kafka producer for event publishing (optional).
 	// If nil, events will not be published.
 	Producer EventPublisher
@@ -109,6 +118,11 @@ func NewClient(cfg *Config, logger *logging.Logger) (*Client, error) {
 		timeout = 60 * time.Second
 	}
 
+	bodyLimit, err := base.ResponseLimit(cfg.MaxResponseSize)
+	if err != nil {
+		return nil, err
+	}
+
 	baseURL := ProductionBaseURL
 	if cfg.Sandbox {
 		baseURL = SandboxBaseURL
@@ -123,8 +137,18 @@ func NewClient(cfg *Config, logger *logging.Logger) (*Client, error) {
 		})
 	}
 
+	httpClient := &http.Client{Timeout: timeout}
+	if cfg.RateLimit != nil {
+		transport, err := base.NewRateLimitTransport(cfg.RateLimit, "mpesa", nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit config: %w", err)
+		}
+		httpClient.Transport = transport
+	}
+
 	return &Client{
-		httpClient:             &http.Client{Timeout: timeout},
+		httpClient:             httpClient,
+		bodyLimit:              bodyLimit,
 		baseURL:                baseURL,
 		apiKey:                 cfg.APIKey,
 		publicKey:              cfg.PublicKey,
@@ -424,7 +448,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body, resul
 	}
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	respBody, err := base.ReadBody(resp.Body, c.bodyLimit)
 	if err != nil {
 		return fmt.Errorf("failed to read response: %w", err)
 	}
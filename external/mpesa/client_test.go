@@ -15,6 +15,8 @@ import (
 	"github.com/Dorico-Dynamics/txova-go-types/contact"
 	"github.com/Dorico-Dynamics/txova-go-types/ids"
 	"github.com/Dorico-Dynamics/txova-go-types/money"
+
+	"github.com/Dorico-Dynamics/txova-go-clients/base"
 )
 
 // mockPublisher is a mock implementation of EventPublisher for testing.
@@ -123,6 +125,20 @@ func TestNewClient(t *testing.T) {
 		}
 	})
 
+	t.Run("returns error with invalid rate limit", func(t *testing.T) {
+		cfg := &Config{
+			APIKey:              "test-api-key",
+			PublicKey:           "test-public-key",
+			ServiceProviderCode: "171717",
+			Origin:              "developer.mpesa.vm.co.mz",
+			RateLimit:           &base.RateLimitConfig{Rate: 1, Burst: -1},
+		}
+		_, err := NewClient(cfg, nil)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
 	t.Run("returns error for AllowUnencryptedAPIKey in production", func(t *testing.T) {
 		cfg := &Config{
 			APIKey:                 "test-api-key",
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/Dorico-Dynamics/txova-go-core/logging"
+
+	"github.com/Dorico-Dynamics/txova-go-clients/base"
 )
 
 // Firebase Cloud Messaging API endpoint.
@@ -18,6 +20,7 @@ const fcmAPIURL = "https://fcm.googleapis.com/v1/projects/%s/messages:send"
 // Client is the push notification client for Firebase Cloud Messaging.
 type Client struct {
 	httpClient  *http.Client
+	bodyLimit   int64
 	projectID   string
 	accessToken string
 	apiURL      string
@@ -35,6 +38,12 @@ type Config struct {
 
 	// Timeout is the request timeout (default: 30s).
 	Timeout time.Duration
+
+	// MaxResponseSize is the maximum size of a response body in bytes (default: 10 MiB).
+	MaxResponseSize int64
+
+	// RateLimit limits the rate of requests to the provider. Disabled if nil.
+	RateLimit *base.RateLimitConfig
 }
 
 // NewClient creates a new push notification client.
@@ -54,8 +63,23 @@ func NewClient(cfg *Config, logger *logging.Logger) (*Client, error) {
 		timeout = 30 * time.Second
 	}
 
+	bodyLimit, err := base.ResponseLimit(cfg.MaxResponseSize)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &http.Client{Timeout: timeout}
+	if cfg.RateLimit != nil {
+		transport, err := base.NewRateLimitTransport(cfg.RateLimit, "fcm", nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit config: %w", err)
+		}
+		httpClient.Transport = transport
+	}
+
 	return &Client{
-		httpClient:  &http.Client{Timeout: timeout},
+		httpClient:  httpClient,
+		bodyLimit:   bodyLimit,
 		projectID:   cfg.ProjectID,
 		accessToken: cfg.AccessToken,
 		apiURL:      fcmAPIURL,
@@ -255,8 +279,13 @@ func (c *Client) sendMessage(ctx context.Context, msg Message) (*SendResult, err
 	}
 	defer resp.Body.Close()
 
+	respBody, err := base.ReadBody(resp.Body, c.bodyLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
 	var fcmResp fcmResponse
-	if err := json.NewDecoder(resp.Body).Decode(&fcmResp); err != nil {
+	if err := json.Unmarshal(respBody, &fcmResp); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
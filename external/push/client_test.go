@@ -7,6 +7,8 @@ import (
 	"net/http/httptest"
 	"testing"
 	"time"
+
+	"github.com/Dorico-Dynamics/txova-go-clients/base"
 )
 
 func TestNewClient(t *testing.T) {
@@ -50,6 +52,17 @@ func TestNewClient(t *testing.T) {
 			t.Fatal("expected error, got nil")
 		}
 	})
+	t.Run("returns error with invalid rate limit", func(t *testing.T) {
+		cfg := &Config{
+			ProjectID:   "test-project",
+			AccessToken: "test-token",
+			RateLimit:   &base.RateLimitConfig{Rate: -1},
+		}
+		_, err := NewClient(cfg, nil)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
 }
 
 func TestSendToDevice(t *testing.T) {
@@ -348,6 +361,30 @@ func TestSetAPIURL(t *testing.T) {
 	}
 }
 
+func TestMaxResponseSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"name": "projects/test/messages/12345"}`))
+	}))
+	defer server.Close()
+
+	cfg := &Config{
+		ProjectID:       "test-project",
+		AccessToken:     "test-token",
+		MaxResponseSize: 16,
+	}
+	client, err := NewClient(cfg, nil)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	client.SetAPIURL(server.URL + "/%s/messages:send")
+
+	_, err = client.SendToDevice(context.Background(), "test-token", &Notification{Title: "Test"}, nil)
+	if !base.IsResponseTooLarge(err) {
+		t.Errorf("expected response too large error, got %v", err)
+	}
+}
+
 func createTestClient(t *testing.T, testServerURL string) *Client {
 	t.Helper()
 
@@ -14,6 +14,8 @@ import (
 
 	"github.com/Dorico-Dynamics/txova-go-core/logging"
 	"github.com/Dorico-Dynamics/txova-go-types/contact"
+
+	"github.com/Dorico-Dynamics/txova-go-clients/base"
 )
 
 // Africa's Talking API endpoints.
@@ -25,6 +27,7 @@ const (
 // Client is the SMS client for Africa's Talking.
 type Client struct {
 	httpClient *http.Client
+	bodyLimit  int64
 	baseURL    string
 	balanceURL string
 	username   string
@@ -49,6 +52,12 @@ type Config struct {
 
 	// Timeout is the request timeout (default: 30s).
 	Timeout time.Duration
+
+	// MaxResponseSize is the maximum size of a response body in bytes (default: 10 MiB).
+	MaxResponseSize int64
+
+	// RateLimit limits the rate of requests to the provider. Disabled if nil.
+	RateLimit *base.RateLimitConfig
 }
 
 // NewClient creates a new SMS client.
@@ -68,13 +77,28 @@ func NewClient(cfg *Config, logger *logging.Logger) (*Client, error) {
 		timeout = 30 * time.Second
 	}
 
+	bodyLimit, err := base.ResponseLimit(cfg.MaxResponseSize)
+	if err != nil {
+		return nil, err
+	}
+
 	baseURL := productionBaseURL
 	if cfg.Sandbox {
 		baseURL = sandboxBaseURL
 	}
 
+	httpClient := &http.Client{Timeout: timeout}
+	if cfg.RateLimit != nil {
+		transport, err := base.NewRateLimitTransport(cfg.RateLimit, "africastalking", nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit config: %w", err)
+		}
+		httpClient.Transport = transport
+	}
+
 	return &Client{
-		httpClient: &http.Client{Timeout: timeout},
+		httpClient: httpClient,
+		bodyLimit:  bodyLimit,
 		baseURL:    baseURL,
 		balanceURL: "https://api.africastalking.com/version1/user",
 		username:   cfg.Username,
@@ -176,7 +200,7 @@ func (c *Client) sendSMS(ctx context.Context, recipients []string, message strin
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := base.ReadBody(resp.Body, c.bodyLimit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
@@ -215,7 +239,7 @@ func (c *Client) GetBalance(ctx context.Context) (*Balance, error) {
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := base.ReadBody(resp.Body, c.bodyLimit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
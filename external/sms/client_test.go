@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/Dorico-Dynamics/txova-go-types/contact"
+
+	"github.com/Dorico-Dynamics/txova-go-clients/base"
 )
 
 func TestNewClient(t *testing.T) {
@@ -71,6 +73,85 @@ func TestNewClient(t *testing.T) {
 			t.Fatal("expected error, got nil")
 		}
 	})
+	t.Run("returns error with invalid rate limit", func(t *testing.T) {
+		cfg := &Config{
+			Username:  "testuser",
+			APIKey:    "testapikey",
+			RateLimit: &base.RateLimitConfig{},
+		}
+		_, err := NewClient(cfg, nil)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("rate limits requests", func(t *testing.T) {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"SMSMessageData": {"Recipients": [{"status": "Success", "messageId": "ATXid_1"}]}}`))
+		}))
+		defer server.Close()
+
+		cfg := &Config{
+			Username:  "testuser",
+			APIKey:    "testapikey",
+			RateLimit: &base.RateLimitConfig{Rate: 1, Burst: 1, Mode: base.RateLimitModeFail},
+		}
+		client, err := NewClient(cfg, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		client.baseURL = server.URL
+
+		phone := contact.MustParsePhoneNumber("841234567")
+		if _, err := client.Send(context.Background(), phone, "first"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := client.Send(context.Background(), phone, "second"); !base.IsRateLimitExceeded(err) {
+			t.Errorf("expected rate limit exceeded error, got %v", err)
+		}
+		if requests != 1 {
+			t.Errorf("expected 1 request, got %d", requests)
+		}
+	})
+
+	t.Run("returns error with negative max response size", func(t *testing.T) {
+		cfg := &Config{
+			Username:        "testuser",
+			APIKey:          "testapikey",
+			MaxResponseSize: -1,
+		}
+		_, err := NewClient(cfg, nil)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("limits response size", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"SMSMessageData": {"Recipients": [{"status": "Success", "messageId": "ATXid_1"}]}}`))
+		}))
+		defer server.Close()
+
+		cfg := &Config{
+			Username:        "testuser",
+			APIKey:          "testapikey",
+			MaxResponseSize: 16,
+		}
+		client, err := NewClient(cfg, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		client.baseURL = server.URL
+
+		phone := contact.MustParsePhoneNumber("841234567")
+		if _, err := client.Send(context.Background(), phone, "hello"); !base.IsResponseTooLarge(err) {
+			t.Errorf("expected response too large error, got %v", err)
+		}
+	})
 }
 
 func TestSend(t *testing.T) {
@@ -37,11 +37,33 @@ type Config struct {
 	// SafetyServiceURL is the base URL of the Safety Service.
 	SafetyServiceURL string
 
+	// MaxResponseSize is the default maximum size of a response body in bytes for all clients.
+	MaxResponseSize int64
+
 	// Retry is the default retry configuration for all clients.
+	// A Retry.Budget is shared by all clients created by the factory.
 	Retry base.RetryConfig
 
 	// CircuitBreaker is the default circuit breaker configuration for all clients.
 	CircuitBreaker *base.CircuitBreakerConfig
+
+	// Bulkhead is the default concurrency limit for all clients.
+	// Every client gets its own limiter.
+	Bulkhead *base.BulkheadConfig
+
+	// RateLimit is the default rate limit for all clients.
+	// Every client gets its own limiter.
+	RateLimit *base.RateLimitConfig
+
+	// Cache is the default response cache configuration for all clients.
+	// Clients share Cache.Cache if set; otherwise every client gets its own LRU cache.
+	Cache *base.CacheConfig
+
+	// Coalescing is the default request coalescing configuration for all clients.
+	Coalescing *base.CoalescingConfig
+
+	// Hedging is the hedging configuration for the Driver and Pricing Service clients.
+	Hedging *base.HedgingConfig
 }
 
 // Factory is a client factory that creates and manages service clients.
@@ -93,9 +115,14 @@ func (f *Factory) User() (*user.Client, error) {
 	}
 
 	cfg := &user.Config{
-		BaseURL:        f.cfg.UserServiceURL,
-		Retry:          f.cfg.Retry,
-		CircuitBreaker: f.cfg.CircuitBreaker,
+		BaseURL:         f.cfg.UserServiceURL,
+		MaxResponseSize: f.cfg.MaxResponseSize,
+		Retry:           f.cfg.Retry,
+		CircuitBreaker:  f.cfg.CircuitBreaker,
+		Bulkhead:        f.cfg.Bulkhead,
+		RateLimit:       f.cfg.RateLimit,
+		Cache:           f.cfg.Cache,
+		Coalescing:      f.cfg.Coalescing,
 	}
 
 	client, err := user.NewClient(cfg, f.logger)
@@ -129,9 +156,15 @@ func (f *Factory) Driver() (*driver.Client, error) {
 	}
 
 	cfg := &driver.Config{
-		BaseURL:        f.cfg.DriverServiceURL,
-		Retry:          f.cfg.Retry,
-		CircuitBreaker: f.cfg.CircuitBreaker,
+		BaseURL:         f.cfg.DriverServiceURL,
+		MaxResponseSize: f.cfg.MaxResponseSize,
+		Retry:           f.cfg.Retry,
+		CircuitBreaker:  f.cfg.CircuitBreaker,
+		Bulkhead:        f.cfg.Bulkhead,
+		RateLimit:       f.cfg.RateLimit,
+		Cache:           f.cfg.Cache,
+		Coalescing:      f.cfg.Coalescing,
+		Hedging:         f.cfg.Hedging,
 	}
 
 	client, err := driver.NewClient(cfg, f.logger)
@@ -165,9 +198,14 @@ func (f *Factory) Ride() (*ride.Client, error) {
 	}
 
 	cfg := &ride.Config{
-		BaseURL:        f.cfg.RideServiceURL,
-		Retry:          f.cfg.Retry,
-		CircuitBreaker: f.cfg.CircuitBreaker,
+		BaseURL:         f.cfg.RideServiceURL,
+		MaxResponseSize: f.cfg.MaxResponseSize,
+		Retry:           f.cfg.Retry,
+		CircuitBreaker:  f.cfg.CircuitBreaker,
+		Bulkhead:        f.cfg.Bulkhead,
+		RateLimit:       f.cfg.RateLimit,
+		Cache:           f.cfg.Cache,
+		Coalescing:      f.cfg.Coalescing,
 	}
 
 	client, err := ride.NewClient(cfg, f.logger)
@@ -201,9 +239,14 @@ func (f *Factory) Payment() (*payment.Client, error) {
 	}
 
 	cfg := &payment.Config{
-		BaseURL:        f.cfg.PaymentServiceURL,
-		Retry:          f.cfg.Retry,
-		CircuitBreaker: f.cfg.CircuitBreaker,
+		BaseURL:         f.cfg.PaymentServiceURL,
+		MaxResponseSize: f.cfg.MaxResponseSize,
+		Retry:           f.cfg.Retry,
+		CircuitBreaker:  f.cfg.CircuitBreaker,
+		Bulkhead:        f.cfg.Bulkhead,
+		RateLimit:       f.cfg.RateLimit,
+		Cache:           f.cfg.Cache,
+		Coalescing:      f.cfg.Coalescing,
 	}
 
 	client, err := payment.NewClient(cfg, f.logger)
@@ -237,9 +280,15 @@ func (f *Factory) Pricing() (*pricing.Client, error) {
 	}
 
 	cfg := &pricing.Config{
-		BaseURL:        f.cfg.PricingServiceURL,
-		Retry:          f.cfg.Retry,
-		CircuitBreaker: f.cfg.CircuitBreaker,
+		BaseURL:         f.cfg.PricingServiceURL,
+		MaxResponseSize: f.cfg.MaxResponseSize,
+		Retry:           f.cfg.Retry,
+		CircuitBreaker:  f.cfg.CircuitBreaker,
+		Bulkhead:        f.cfg.Bulkhead,
+		RateLimit:       f.cfg.RateLimit,
+		Cache:           f.cfg.Cache,
+		Coalescing:      f.cfg.Coalescing,
+		Hedging:         f.cfg.Hedging,
 	}
 
 	client, err := pricing.NewClient(cfg, f.logger)
@@ -273,9 +322,14 @@ func (f *Factory) Safety() (*safety.Client, error) {
 	}
 
 	cfg := &safety.Config{
-		BaseURL:        f.cfg.SafetyServiceURL,
-		Retry:          f.cfg.Retry,
-		CircuitBreaker: f.cfg.CircuitBreaker,
+		BaseURL:         f.cfg.SafetyServiceURL,
+		MaxResponseSize: f.cfg.MaxResponseSize,
+		Retry:           f.cfg.Retry,
+		CircuitBreaker:  f.cfg.CircuitBreaker,
+		Bulkhead:        f.cfg.Bulkhead,
+		RateLimit:       f.cfg.RateLimit,
+		Cache:           f.cfg.Cache,
+		Coalescing:      f.cfg.Coalescing,
 	}
 
 	client, err := safety.NewClient(cfg, f.logger)
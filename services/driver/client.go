@@ -28,14 +28,41 @@ type Config struct {
 	// BaseURL is the base URL of the Driver Service (required).
 	BaseURL string
 
-	// Timeout is the request timeout (default: 10s).
+	// Timeout is the total time budget for a call, including retries (default: 10s).
 	Timeout time.Duration
 
+	// RequestTimeout is the timeout for a single attempt (default: a third of Timeout).
+	RequestTimeout time.Duration
+
+	// MaxResponseSize is the maximum size of a response body in bytes (default: 10 MiB).
+	MaxResponseSize int64
+
 	// RetryConfig is the retry configuration.
 	Retry base.RetryConfig
 
 	// CircuitBreaker is the circuit breaker configuration.
 	CircuitBreaker *base.CircuitBreakerConfig
+
+	// Bulkhead limits concurrent calls to the service. Disabled if nil.
+	Bulkhead *base.BulkheadConfig
+
+	// RateLimit limits the rate of requests to the service. Disabled if nil.
+	RateLimit *base.RateLimitConfig
+
+	// LoadBalancer spreads requests across service instances instead of the BaseURL. Disabled if nil.
+	LoadBalancer *base.LoadBalancerConfig
+
+	// Failover sends requests to a secondary deployment while BaseURL is unavailable. Disabled if nil.
+	Failover *base.FailoverConfig
+
+	// Cache caches GET responses following their HTTP caching headers. Disabled if nil.
+	Cache *base.CacheConfig
+
+	// Coalescing shares one response between identical concurrent GET calls. Disabled if nil.
+	Coalescing *base.CoalescingConfig
+
+	// Hedging enables hedged requests for latency-sensitive calls. Disabled if nil.
+	Hedging *base.HedgingConfig
 }
 
 // DefaultConfig returns a default configuration for the Driver Service client.
@@ -54,11 +81,19 @@ func NewClient(cfg *Config, logger *logging.Logger) (*Client, error) {
 	}
 
 	baseCfg := &base.Config{
-		BaseURL:        cfg.BaseURL,
-		Timeout:        cfg.Timeout,
-		RequestTimeout: cfg.Timeout,
-		Retry:          cfg.Retry,
-		CircuitBreaker: cfg.CircuitBreaker,
+		BaseURL:         cfg.BaseURL,
+		Timeout:         cfg.Timeout,
+		RequestTimeout:  cfg.RequestTimeout,
+		MaxResponseSize: cfg.MaxResponseSize,
+		Retry:           cfg.Retry,
+		CircuitBreaker:  cfg.CircuitBreaker,
+		Bulkhead:        cfg.Bulkhead,
+		RateLimit:       cfg.RateLimit,
+		LoadBalancer:    cfg.LoadBalancer,
+		Failover:        cfg.Failover,
+		Cache:           cfg.Cache,
+		Coalescing:      cfg.Coalescing,
+		Hedging:         cfg.Hedging,
 	}
 
 	baseClient, err := base.NewClient(baseCfg, logger)
@@ -110,13 +145,7 @@ func (c *Client) GetDriver(ctx context.Context, driverID ids.DriverID) (*Driver,
 		return nil, fmt.Errorf("driver ID is required")
 	}
 
-	var driver Driver
-	err := c.client.Get(ctx, fmt.Sprintf("/drivers/%s", driverID)).Decode(&driver)
-	if err != nil {
-		return nil, err
-	}
-
-	return &driver, nil
+	return base.GetJSON[Driver](ctx, c.client, "/drivers/{driver_id}", base.Param("driver_id", driverID))
 }
 
 // GetDriverByUserID retrieves a driver by their user ID.
@@ -125,15 +154,8 @@ func (c *Client) GetDriverByUserID(ctx context.Context, userID ids.UserID) (*Dri
 		return nil, fmt.Errorf("user ID is required")
 	}
 
-	var driver Driver
-	err := c.client.Get(ctx, "/drivers/by-user").
-		WithQuery("user_id", userID.String()).
-		Decode(&driver)
-	if err != nil {
-		return nil, err
-	}
-
-	return &driver, nil
+	return base.GetJSON[Driver](ctx, c.client, "/drivers/by-user",
+		base.Query("user_id", userID.String()))
 }
 
 // GetActiveVehicle retrieves the active vehicle for a driver.
@@ -142,13 +164,7 @@ func (c *Client) GetActiveVehicle(ctx context.Context, driverID ids.DriverID) (*
 		return nil, fmt.Errorf("driver ID is required")
 	}
 
-	var v Vehicle
-	err := c.client.Get(ctx, fmt.Sprintf("/drivers/%s/vehicle", driverID)).Decode(&v)
-	if err != nil {
-		return nil, err
-	}
-
-	return &v, nil
+	return base.GetJSON[Vehicle](ctx, c.client, "/drivers/{driver_id}/vehicle", base.Param("driver_id", driverID))
 }
 
 // RecordEarningsRequest is the request body for recording driver earnings.
@@ -171,16 +187,15 @@ func (c *Client) RecordEarnings(ctx context.Context, driverID ids.DriverID, ride
 		Amount: amount,
 	}
 
-	resp, err := c.client.Post(ctx, fmt.Sprintf("/drivers/%s/earnings", driverID), req).Do()
-	if err != nil {
-		return err
-	}
-
-	if !resp.IsSuccess() {
-		return resp.DecodeError()
-	}
+	// A ride has a single earnings record, so the ride ID makes a stable key
+	// that also deduplicates calls repeated by the caller.
+	return base.Exec(ctx, c.client, http.MethodPost, "/drivers/{driver_id}/earnings", req,
+		base.Param("driver_id", driverID), base.IdempotencyKey("earnings-"+rideID.String()))
+}
 
-	return nil
+// statusResponse is the response body of the driver availability status endpoint.
+type statusResponse struct {
+	Status enums.AvailabilityStatus `json:"status"`
 }
 
 // GetDriverStatus retrieves the availability status of a driver.
@@ -189,11 +204,7 @@ func (c *Client) GetDriverStatus(ctx context.Context, driverID ids.DriverID) (en
 		return "", fmt.Errorf("driver ID is required")
 	}
 
-	var response struct {
-		Status enums.AvailabilityStatus `json:"status"`
-	}
-
-	err := c.client.Get(ctx, fmt.Sprintf("/drivers/%s/status", driverID)).Decode(&response)
+	response, err := base.GetJSON[statusResponse](ctx, c.client, "/drivers/{driver_id}/status", base.Param("driver_id", driverID))
 	if err != nil {
 		return "", err
 	}
@@ -201,17 +212,18 @@ func (c *Client) GetDriverStatus(ctx context.Context, driverID ids.DriverID) (en
 	return response.Status, nil
 }
 
+// nearbyDriversResponse is the response body of the nearby drivers endpoint.
+type nearbyDriversResponse struct {
+	Drivers []*NearbyDriver `json:"drivers"`
+}
+
 // GetNearbyDrivers retrieves drivers near a location within a radius.
 func (c *Client) GetNearbyDrivers(ctx context.Context, location geo.Location, radiusKM float64) ([]*NearbyDriver, error) {
-	var response struct {
-		Drivers []*NearbyDriver `json:"drivers"`
-	}
-
-	err := c.client.Get(ctx, "/drivers/nearby").
-		WithQuery("lat", fmt.Sprintf("%.6f", location.Latitude())).
-		WithQuery("lon", fmt.Sprintf("%.6f", location.Longitude())).
-		WithQuery("radius_km", fmt.Sprintf("%.2f", radiusKM)).
-		Decode(&response)
+	response, err := base.GetJSON[nearbyDriversResponse](ctx, c.client, "/drivers/nearby",
+		base.Hedged(),
+		base.Query("lat", fmt.Sprintf("%.6f", location.Latitude())),
+		base.Query("lon", fmt.Sprintf("%.6f", location.Longitude())),
+		base.Query("radius_km", fmt.Sprintf("%.2f", radiusKM)))
 	if err != nil {
 		return nil, err
 	}
@@ -236,16 +248,7 @@ func (c *Client) UpdateLocation(ctx context.Context, driverID ids.DriverID, loca
 		Longitude: location.Longitude(),
 	}
 
-	resp, err := c.client.Put(ctx, fmt.Sprintf("/drivers/%s/location", driverID), req).Do()
-	if err != nil {
-		return err
-	}
-
-	if !resp.IsSuccess() {
-		return resp.DecodeError()
-	}
-
-	return nil
+	return base.Exec(ctx, c.client, http.MethodPut, "/drivers/{driver_id}/location", req, base.Param("driver_id", driverID))
 }
 
 // SetAvailability sets the availability status of a driver.
@@ -261,16 +264,7 @@ func (c *Client) SetAvailability(ctx context.Context, driverID ids.DriverID, sta
 		Status enums.AvailabilityStatus `json:"status"`
 	}{Status: status}
 
-	resp, err := c.client.Put(ctx, fmt.Sprintf("/drivers/%s/availability", driverID), req).Do()
-	if err != nil {
-		return err
-	}
-
-	if !resp.IsSuccess() {
-		return resp.DecodeError()
-	}
-
-	return nil
+	return base.Exec(ctx, c.client, http.MethodPut, "/drivers/{driver_id}/availability", req, base.Param("driver_id", driverID))
 }
 
 // HealthCheck checks the health of the Driver Service.
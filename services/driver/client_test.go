@@ -263,6 +263,31 @@ func TestRecordEarnings(t *testing.T) {
 		}
 	})
 
+	t.Run("retries with the same idempotency key", func(t *testing.T) {
+		var keys []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			keys = append(keys, r.Header.Get(base.DefaultIdempotencyHeader))
+			if len(keys) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		defer server.Close()
+
+		client := createTestClient(t, server.URL)
+		if err := client.RecordEarnings(context.Background(), driverID, rideID, amount); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+			t.Errorf("expected the same key on both attempts, got %v", keys)
+		}
+		if len(keys) > 0 && keys[0] != "earnings-"+rideID.String() {
+			t.Errorf("expected key derived from ride ID, got %s", keys[0])
+		}
+	})
+
 	t.Run("returns error for zero driver ID", func(t *testing.T) {
 		client := createTestClient(t, "http://localhost:8080")
 		err := client.RecordEarnings(context.Background(), ids.DriverID{}, rideID, amount)
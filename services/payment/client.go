@@ -25,14 +25,38 @@ type Config struct {
 	// BaseURL is the base URL of the Payment Service (required).
 	BaseURL string
 
-	// Timeout is the request timeout (default: 15s for payment operations).
+	// Timeout is the total time budget for a call, including retries (default: 15s for payment operations).
 	Timeout time.Duration
 
+	// RequestTimeout is the timeout for a single attempt (default: a third of Timeout).
+	RequestTimeout time.Duration
+
+	// MaxResponseSize is the maximum size of a response body in bytes (default: 10 MiB).
+	MaxResponseSize int64
+
 	// RetryConfig is the retry configuration.
 	Retry base.RetryConfig
 
 	// CircuitBreaker is the circuit breaker configuration.
 	CircuitBreaker *base.CircuitBreakerConfig
+
+	// Bulkhead limits concurrent calls to the service. Disabled if nil.
+	Bulkhead *base.BulkheadConfig
+
+	// RateLimit limits the rate of requests to the service. Disabled if nil.
+	RateLimit *base.RateLimitConfig
+
+	// LoadBalancer spreads requests across service instances instead of the BaseURL. Disabled if nil.
+	LoadBalancer *base.LoadBalancerConfig
+
+	// Failover sends requests to a secondary deployment while BaseURL is unavailable. Disabled if nil.
+	Failover *base.FailoverConfig
+
+	// Cache caches GET responses following their HTTP caching headers. Disabled if nil.
+	Cache *base.CacheConfig
+
+	// Coalescing shares one response between identical concurrent GET calls. Disabled if nil.
+	Coalescing *base.CoalescingConfig
 }
 
 // DefaultConfig returns a default configuration for the Payment Service client.
@@ -51,11 +75,18 @@ func NewClient(cfg *Config, logger *logging.Logger) (*Client, error) {
 	}
 
 	baseCfg := &base.Config{
-		BaseURL:        cfg.BaseURL,
-		Timeout:        cfg.Timeout,
-		RequestTimeout: cfg.Timeout,
-		Retry:          cfg.Retry,
-		CircuitBreaker: cfg.CircuitBreaker,
+		BaseURL:         cfg.BaseURL,
+		Timeout:         cfg.Timeout,
+		RequestTimeout:  cfg.RequestTimeout,
+		MaxResponseSize: cfg.MaxResponseSize,
+		Retry:           cfg.Retry,
+		CircuitBreaker:  cfg.CircuitBreaker,
+		Bulkhead:        cfg.Bulkhead,
+		RateLimit:       cfg.RateLimit,
+		LoadBalancer:    cfg.LoadBalancer,
+		Failover:        cfg.Failover,
+		Cache:           cfg.Cache,
+		Coalescing:      cfg.Coalescing,
 	}
 
 	baseClient, err := base.NewClient(baseCfg, logger)
@@ -105,13 +136,7 @@ func (c *Client) GetPayment(ctx context.Context, paymentID ids.PaymentID) (*Paym
 		return nil, fmt.Errorf("payment ID is required")
 	}
 
-	var payment Payment
-	err := c.client.Get(ctx, fmt.Sprintf("/payments/%s", paymentID)).Decode(&payment)
-	if err != nil {
-		return nil, err
-	}
-
-	return &payment, nil
+	return base.GetJSON[Payment](ctx, c.client, "/payments/{payment_id}", base.Param("payment_id", paymentID))
 }
 
 // GetPaymentByRide retrieves the payment for a ride.
@@ -120,15 +145,8 @@ func (c *Client) GetPaymentByRide(ctx context.Context, rideID ids.RideID) (*Paym
 		return nil, fmt.Errorf("ride ID is required")
 	}
 
-	var payment Payment
-	err := c.client.Get(ctx, "/payments/by-ride").
-		WithQuery("ride_id", rideID.String()).
-		Decode(&payment)
-	if err != nil {
-		return nil, err
-	}
-
-	return &payment, nil
+	return base.GetJSON[Payment](ctx, c.client, "/payments/by-ride",
+		base.Query("ride_id", rideID.String()))
 }
 
 // InitiateRefundRequest is the request body for initiating a refund.
@@ -151,13 +169,9 @@ func (c *Client) InitiateRefund(ctx context.Context, paymentID ids.PaymentID, am
 		Reason: reason,
 	}
 
-	var refund Refund
-	err := c.client.Post(ctx, fmt.Sprintf("/payments/%s/refund", paymentID), req).Decode(&refund)
-	if err != nil {
-		return nil, err
-	}
-
-	return &refund, nil
+	// The key lets the payment service deduplicate retried refunds.
+	return base.PostJSON[Refund](ctx, c.client, "/payments/{payment_id}/refund", req,
+		base.Param("payment_id", paymentID), base.IdempotencyKey(base.NewIdempotencyKey()))
 }
 
 // GetWalletBalance retrieves the wallet balance for a user.
@@ -166,13 +180,12 @@ func (c *Client) GetWalletBalance(ctx context.Context, userID ids.UserID) (*Wall
 		return nil, fmt.Errorf("user ID is required")
 	}
 
-	var balance WalletBalance
-	err := c.client.Get(ctx, fmt.Sprintf("/wallets/%s/balance", userID)).Decode(&balance)
-	if err != nil {
-		return nil, err
-	}
+	return base.GetJSON[WalletBalance](ctx, c.client, "/wallets/{user_id}/balance", base.Param("user_id", userID))
+}
 
-	return &balance, nil
+// statusResponse is the response body of the payment status endpoint.
+type statusResponse struct {
+	Status enums.PaymentStatus `json:"status"`
 }
 
 // GetPaymentStatus retrieves the status of a payment.
@@ -181,11 +194,7 @@ func (c *Client) GetPaymentStatus(ctx context.Context, paymentID ids.PaymentID)
 		return "", fmt.Errorf("payment ID is required")
 	}
 
-	var response struct {
-		Status enums.PaymentStatus `json:"status"`
-	}
-
-	err := c.client.Get(ctx, fmt.Sprintf("/payments/%s/status", paymentID)).Decode(&response)
+	response, err := base.GetJSON[statusResponse](ctx, c.client, "/payments/{payment_id}/status", base.Param("payment_id", paymentID))
 	if err != nil {
 		return "", err
 	}
@@ -26,14 +26,41 @@ type Config struct {
 	// BaseURL is the base URL of the Pricing Service (required).
 	BaseURL string
 
-	// Timeout is the request timeout (default: 5s for fast pricing lookups).
+	// Timeout is the total time budget for a call, including retries (default: 5s for fast pricing lookups).
 	Timeout time.Duration
 
+	// RequestTimeout is the timeout for a single attempt (default: a third of Timeout).
+	RequestTimeout time.Duration
+
+	// MaxResponseSize is the maximum size of a response body in bytes (default: 10 MiB).
+	MaxResponseSize int64
+
 	// RetryConfig is the retry configuration.
 	Retry base.RetryConfig
 
 	// CircuitBreaker is the circuit breaker configuration.
 	CircuitBreaker *base.CircuitBreakerConfig
+
+	// Bulkhead limits concurrent calls to the service. Disabled if nil.
+	Bulkhead *base.BulkheadConfig
+
+	// RateLimit limits the rate of requests to the service. Disabled if nil.
+	RateLimit *base.RateLimitConfig
+
+	// LoadBalancer spreads requests across service instances instead of the BaseURL. Disabled if nil.
+	LoadBalancer *base.LoadBalancerConfig
+
+	// Failover sends requests to a secondary deployment while BaseURL is unavailable. Disabled if nil.
+	Failover *base.FailoverConfig
+
+	// Cache caches GET responses following their HTTP caching headers. Disabled if nil.
+	Cache *base.CacheConfig
+
+	// Coalescing shares one response between identical concurrent GET calls. Disabled if nil.
+	Coalescing *base.CoalescingConfig
+
+	// Hedging enables hedged requests for latency-sensitive calls. Disabled if nil.
+	Hedging *base.HedgingConfig
 }
 
 // DefaultConfig returns a default configuration for the Pricing Service client.
@@ -52,11 +79,19 @@ func NewClient(cfg *Config, logger *logging.Logger) (*Client, error) {
 	}
 
 	baseCfg := &base.Config{
-		BaseURL:        cfg.BaseURL,
-		Timeout:        cfg.Timeout,
-		RequestTimeout: cfg.Timeout,
-		Retry:          cfg.Retry,
-		CircuitBreaker: cfg.CircuitBreaker,
+		BaseURL:         cfg.BaseURL,
+		Timeout:         cfg.Timeout,
+		RequestTimeout:  cfg.RequestTimeout,
+		MaxResponseSize: cfg.MaxResponseSize,
+		Retry:           cfg.Retry,
+		CircuitBreaker:  cfg.CircuitBreaker,
+		Bulkhead:        cfg.Bulkhead,
+		RateLimit:       cfg.RateLimit,
+		LoadBalancer:    cfg.LoadBalancer,
+		Failover:        cfg.Failover,
+		Cache:           cfg.Cache,
+		Coalescing:      cfg.Coalescing,
+		Hedging:         cfg.Hedging,
 	}
 
 	baseClient, err := base.NewClient(baseCfg, logger)
@@ -124,27 +159,17 @@ func (c *Client) GetEstimate(ctx context.Context, pickup, dropoff geo.Location,
 		ServiceType: serviceType,
 	}
 
-	var estimate FareEstimate
-	err := c.client.Post(ctx, "/pricing/estimate", req).Decode(&estimate)
-	if err != nil {
-		return nil, err
-	}
-
-	return &estimate, nil
+	// Estimates have no side effects, so they can be hedged like reads.
+	return base.PostJSON[FareEstimate](ctx, c.client, "/pricing/estimate", req,
+		base.Idempotent(),
+		base.Hedged())
 }
 
 // GetSurgeMultiplier retrieves the current surge multiplier for a location.
 func (c *Client) GetSurgeMultiplier(ctx context.Context, location geo.Location) (*SurgeInfo, error) {
-	var surge SurgeInfo
-	err := c.client.Get(ctx, "/pricing/surge").
-		WithQuery("lat", fmt.Sprintf("%.6f", location.Latitude())).
-		WithQuery("lon", fmt.Sprintf("%.6f", location.Longitude())).
-		Decode(&surge)
-	if err != nil {
-		return nil, err
-	}
-
-	return &surge, nil
+	return base.GetJSON[SurgeInfo](ctx, c.client, "/pricing/surge",
+		base.Query("lat", fmt.Sprintf("%.6f", location.Latitude())),
+		base.Query("lon", fmt.Sprintf("%.6f", location.Longitude())))
 }
 
 // ValidateFareRequest is the request body for validating a fare.
@@ -160,22 +185,17 @@ func (c *Client) ValidateFare(ctx context.Context, rideID ids.RideID, fare money
 
 	req := ValidateFareRequest{Fare: fare}
 
-	var validation FareValidation
-	err := c.client.Post(ctx, fmt.Sprintf("/pricing/validate/%s", rideID), req).Decode(&validation)
-	if err != nil {
-		return nil, err
-	}
+	return base.PostJSON[FareValidation](ctx, c.client, "/pricing/validate/{ride_id}", req, base.Param("ride_id", rideID))
+}
 
-	return &validation, nil
+// serviceTypesResponse is the response body of the service types endpoint.
+type serviceTypesResponse struct {
+	ServiceTypes []ServiceTypePricing `json:"service_types"`
 }
 
 // GetServiceTypes retrieves all available service types with their base pricing.
 func (c *Client) GetServiceTypes(ctx context.Context) ([]ServiceTypePricing, error) {
-	var response struct {
-		ServiceTypes []ServiceTypePricing `json:"service_types"`
-	}
-
-	err := c.client.Get(ctx, "/pricing/service-types").Decode(&response)
+	response, err := base.GetJSON[serviceTypesResponse](ctx, c.client, "/pricing/service-types")
 	if err != nil {
 		return nil, err
 	}
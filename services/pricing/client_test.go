@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -110,6 +111,53 @@ func TestGetEstimate(t *testing.T) {
 		}
 	})
 
+	t.Run("hedges slow estimates", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(2 * time.Second):
+				}
+			}
+
+			var req GetEstimateRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("failed to decode request: %v", err)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(FareEstimate{ServiceType: req.ServiceType})
+		}))
+		defer server.Close()
+
+		cfg := &Config{
+			BaseURL: server.URL,
+			Timeout: 5 * time.Second,
+			Retry:   base.RetryConfig{MaxRetries: 0},
+			Hedging: &base.HedgingConfig{Delay: 20 * time.Millisecond},
+		}
+		client, err := NewClient(cfg, nil)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		start := time.Now()
+		estimate, err := client.GetEstimate(context.Background(), pickup, dropoff, enums.ServiceTypeStandard)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if estimate.ServiceType != enums.ServiceTypeStandard {
+			t.Errorf("expected service type 'standard', got %s", estimate.ServiceType)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("expected the hedged request to answer, took %v", elapsed)
+		}
+		if atomic.LoadInt32(&calls) != 2 {
+			t.Errorf("expected 2 requests, got %d", calls)
+		}
+	})
+
 	t.Run("returns error for invalid service type", func(t *testing.T) {
 		client := createTestClient(t, "http://localhost:8080")
 		_, err := client.GetEstimate(context.Background(), pickup, dropoff, enums.ServiceType("invalid"))
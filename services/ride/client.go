@@ -28,14 +28,38 @@ type Config struct {
 	// BaseURL is the base URL of the Ride Service (required).
 	BaseURL string
 
-	// Timeout is the request timeout (default: 10s).
+	// Timeout is the total time budget for a call, including retries (default: 10s).
 	Timeout time.Duration
 
+	// RequestTimeout is the timeout for a single attempt (default: a third of Timeout).
+	RequestTimeout time.Duration
+
+	// MaxResponseSize is the maximum size of a response body in bytes (default: 10 MiB).
+	MaxResponseSize int64
+
 	// RetryConfig is the retry configuration.
 	Retry base.RetryConfig
 
 	// CircuitBreaker is the circuit breaker configuration.
 	CircuitBreaker *base.CircuitBreakerConfig
+
+	// Bulkhead limits concurrent calls to the service. Disabled if nil.
+	Bulkhead *base.BulkheadConfig
+
+	// RateLimit limits the rate of requests to the service. Disabled if nil.
+	RateLimit *base.RateLimitConfig
+
+	// LoadBalancer spreads requests across service instances instead of the BaseURL. Disabled if nil.
+	LoadBalancer *base.LoadBalancerConfig
+
+	// Failover sends requests to a secondary deployment while BaseURL is unavailable. Disabled if nil.
+	Failover *base.FailoverConfig
+
+	// Cache caches GET responses following their HTTP caching headers. Disabled if nil.
+	Cache *base.CacheConfig
+
+	// Coalescing shares one response between identical concurrent GET calls. Disabled if nil.
+	Coalescing *base.CoalescingConfig
 }
 
 // DefaultConfig returns a default configuration for the Ride Service client.
@@ -54,11 +78,18 @@ func NewClient(cfg *Config, logger *logging.Logger) (*Client, error) {
 	}
 
 	baseCfg := &base.Config{
-		BaseURL:        cfg.BaseURL,
-		Timeout:        cfg.Timeout,
-		RequestTimeout: cfg.Timeout,
-		Retry:          cfg.Retry,
-		CircuitBreaker: cfg.CircuitBreaker,
+		BaseURL:         cfg.BaseURL,
+		Timeout:         cfg.Timeout,
+		RequestTimeout:  cfg.RequestTimeout,
+		MaxResponseSize: cfg.MaxResponseSize,
+		Retry:           cfg.Retry,
+		CircuitBreaker:  cfg.CircuitBreaker,
+		Bulkhead:        cfg.Bulkhead,
+		RateLimit:       cfg.RateLimit,
+		LoadBalancer:    cfg.LoadBalancer,
+		Failover:        cfg.Failover,
+		Cache:           cfg.Cache,
+		Coalescing:      cfg.Coalescing,
 	}
 
 	baseClient, err := base.NewClient(baseCfg, logger)
@@ -98,13 +129,7 @@ func (c *Client) GetRide(ctx context.Context, rideID ids.RideID) (*Ride, error)
 		return nil, fmt.Errorf("ride ID is required")
 	}
 
-	var ride Ride
-	err := c.client.Get(ctx, fmt.Sprintf("/rides/%s", rideID)).Decode(&ride)
-	if err != nil {
-		return nil, err
-	}
-
-	return &ride, nil
+	return base.GetJSON[Ride](ctx, c.client, "/rides/{ride_id}", base.Param("ride_id", rideID))
 }
 
 // GetActiveRide retrieves the active ride for a user.
@@ -113,15 +138,8 @@ func (c *Client) GetActiveRide(ctx context.Context, userID ids.UserID) (*Ride, e
 		return nil, fmt.Errorf("user ID is required")
 	}
 
-	var ride Ride
-	err := c.client.Get(ctx, "/rides/active").
-		WithQuery("user_id", userID.String()).
-		Decode(&ride)
-	if err != nil {
-		return nil, err
-	}
-
-	return &ride, nil
+	return base.GetJSON[Ride](ctx, c.client, "/rides/active",
+		base.Query("user_id", userID.String()))
 }
 
 // GetRideHistory retrieves the ride history for a user with pagination.
@@ -132,17 +150,10 @@ func (c *Client) GetRideHistory(ctx context.Context, userID ids.UserID, page pag
 
 	page = page.Normalize()
 
-	var response pagination.PageResponse[Ride]
-	err := c.client.Get(ctx, "/rides/history").
-		WithQuery("user_id", userID.String()).
-		WithQuery("limit", strconv.Itoa(page.Limit)).
-		WithQuery("offset", strconv.Itoa(page.Offset)).
-		Decode(&response)
-	if err != nil {
-		return nil, err
-	}
-
-	return &response, nil
+	return base.GetJSON[pagination.PageResponse[Ride]](ctx, c.client, "/rides/history",
+		base.Query("user_id", userID.String()),
+		base.Query("limit", strconv.Itoa(page.Limit)),
+		base.Query("offset", strconv.Itoa(page.Offset)))
 }
 
 // CancelRideRequest is the request body for cancelling a ride.
@@ -160,16 +171,12 @@ func (c *Client) CancelRide(ctx context.Context, rideID ids.RideID, reason enums
 	}
 
 	req := CancelRideRequest{Reason: reason}
-	resp, err := c.client.Post(ctx, fmt.Sprintf("/rides/%s/cancel", rideID), req).Do()
-	if err != nil {
-		return err
-	}
-
-	if !resp.IsSuccess() {
-		return resp.DecodeError()
-	}
+	return base.Exec(ctx, c.client, http.MethodPost, "/rides/{ride_id}/cancel", req, base.Param("ride_id", rideID))
+}
 
-	return nil
+// statusResponse is the response body of the ride status endpoint.
+type statusResponse struct {
+	Status enums.RideStatus `json:"status"`
 }
 
 // GetRideStatus retrieves the status of a ride.
@@ -178,11 +185,7 @@ func (c *Client) GetRideStatus(ctx context.Context, rideID ids.RideID) (enums.Ri
 		return "", fmt.Errorf("ride ID is required")
 	}
 
-	var response struct {
-		Status enums.RideStatus `json:"status"`
-	}
-
-	err := c.client.Get(ctx, fmt.Sprintf("/rides/%s/status", rideID)).Decode(&response)
+	response, err := base.GetJSON[statusResponse](ctx, c.client, "/rides/{ride_id}/status", base.Param("ride_id", rideID))
 	if err != nil {
 		return "", err
 	}
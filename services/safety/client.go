@@ -26,14 +26,38 @@ type Config struct {
 	// BaseURL is the base URL of the Safety Service (required).
 	BaseURL string
 
-	// Timeout is the request timeout (default: 10s).
+	// Timeout is the total time budget for a call, including retries (default: 10s).
 	Timeout time.Duration
 
+	// RequestTimeout is the timeout for a single attempt (default: a third of Timeout).
+	RequestTimeout time.Duration
+
+	// MaxResponseSize is the maximum size of a response body in bytes (default: 10 MiB).
+	MaxResponseSize int64
+
 	// RetryConfig is the retry configuration.
 	Retry base.RetryConfig
 
 	// CircuitBreaker is the circuit breaker configuration.
 	CircuitBreaker *base.CircuitBreakerConfig
+
+	// Bulkhead limits concurrent calls to the service. Disabled if nil.
+	Bulkhead *base.BulkheadConfig
+
+	// RateLimit limits the rate of requests to the service. Disabled if nil.
+	RateLimit *base.RateLimitConfig
+
+	// LoadBalancer spreads requests across service instances instead of the BaseURL. Disabled if nil.
+	LoadBalancer *base.LoadBalancerConfig
+
+	// Failover sends requests to a secondary deployment while BaseURL is unavailable. Disabled if nil.
+	Failover *base.FailoverConfig
+
+	// Cache caches GET responses following their HTTP caching headers. Disabled if nil.
+	Cache *base.CacheConfig
+
+	// Coalescing shares one response between identical concurrent GET calls. Disabled if nil.
+	Coalescing *base.CoalescingConfig
 }
 
 // DefaultConfig returns a default configuration for the Safety Service client.
@@ -52,11 +76,18 @@ func NewClient(cfg *Config, logger *logging.Logger) (*Client, error) {
 	}
 
 	baseCfg := &base.Config{
-		BaseURL:        cfg.BaseURL,
-		Timeout:        cfg.Timeout,
-		RequestTimeout: cfg.Timeout,
-		Retry:          cfg.Retry,
-		CircuitBreaker: cfg.CircuitBreaker,
+		BaseURL:         cfg.BaseURL,
+		Timeout:         cfg.Timeout,
+		RequestTimeout:  cfg.RequestTimeout,
+		MaxResponseSize: cfg.MaxResponseSize,
+		Retry:           cfg.Retry,
+		CircuitBreaker:  cfg.CircuitBreaker,
+		Bulkhead:        cfg.Bulkhead,
+		RateLimit:       cfg.RateLimit,
+		LoadBalancer:    cfg.LoadBalancer,
+		Failover:        cfg.Failover,
+		Cache:           cfg.Cache,
+		Coalescing:      cfg.Coalescing,
 	}
 
 	baseClient, err := base.NewClient(baseCfg, logger)
@@ -111,13 +142,7 @@ func (c *Client) GetUserRating(ctx context.Context, userID ids.UserID) (*RatingA
 		return nil, fmt.Errorf("user ID is required")
 	}
 
-	var ra RatingAggregate
-	err := c.client.Get(ctx, fmt.Sprintf("/users/%s/rating", userID)).Decode(&ra)
-	if err != nil {
-		return nil, err
-	}
-
-	return &ra, nil
+	return base.GetJSON[RatingAggregate](ctx, c.client, "/users/{user_id}/rating", base.Param("user_id", userID))
 }
 
 // GetDriverRating retrieves the aggregated rating for a driver.
@@ -126,13 +151,7 @@ func (c *Client) GetDriverRating(ctx context.Context, driverID ids.DriverID) (*R
 		return nil, fmt.Errorf("driver ID is required")
 	}
 
-	var ra RatingAggregate
-	err := c.client.Get(ctx, fmt.Sprintf("/drivers/%s/rating", driverID)).Decode(&ra)
-	if err != nil {
-		return nil, err
-	}
-
-	return &ra, nil
+	return base.GetJSON[RatingAggregate](ctx, c.client, "/drivers/{driver_id}/rating", base.Param("driver_id", driverID))
 }
 
 // ReportIncident reports a safety incident.
@@ -153,13 +172,9 @@ func (c *Client) ReportIncident(ctx context.Context, report *IncidentReport) (*I
 		return nil, fmt.Errorf("description is required")
 	}
 
-	var incident Incident
-	err := c.client.Post(ctx, "/incidents", report).Decode(&incident)
-	if err != nil {
-		return nil, err
-	}
-
-	return &incident, nil
+	// The key lets the safety service deduplicate retried reports.
+	return base.PostJSON[Incident](ctx, c.client, "/incidents", report,
+		base.IdempotencyKey(base.NewIdempotencyKey()))
 }
 
 // GetIncident retrieves an incident by its ID.
@@ -168,13 +183,7 @@ func (c *Client) GetIncident(ctx context.Context, incidentID ids.IncidentID) (*I
 		return nil, fmt.Errorf("incident ID is required")
 	}
 
-	var incident Incident
-	err := c.client.Get(ctx, fmt.Sprintf("/incidents/%s", incidentID)).Decode(&incident)
-	if err != nil {
-		return nil, err
-	}
-
-	return &incident, nil
+	return base.GetJSON[Incident](ctx, c.client, "/incidents/{incident_id}", base.Param("incident_id", incidentID))
 }
 
 // TriggerEmergencyRequest is the request body for triggering an emergency.
@@ -189,16 +198,7 @@ func (c *Client) TriggerEmergency(ctx context.Context, rideID ids.RideID, locati
 	}
 
 	req := TriggerEmergencyRequest{Location: location}
-	resp, err := c.client.Post(ctx, fmt.Sprintf("/rides/%s/emergency", rideID), req).Do()
-	if err != nil {
-		return err
-	}
-
-	if !resp.IsSuccess() {
-		return resp.DecodeError()
-	}
-
-	return nil
+	return base.Exec(ctx, c.client, http.MethodPost, "/rides/{ride_id}/emergency", req, base.Param("ride_id", rideID))
 }
 
 // HealthCheck checks the health of the Safety Service.
@@ -232,6 +232,34 @@ func TestReportIncident(t *testing.T) {
 		}
 	})
 
+	t.Run("retries with the same idempotency key", func(t *testing.T) {
+		var keys []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			keys = append(keys, r.Header.Get(base.DefaultIdempotencyHeader))
+			if len(keys) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		defer server.Close()
+
+		client := createTestClient(t, server.URL)
+		if _, err := client.ReportIncident(context.Background(), &IncidentReport{
+			RideID:      rideID,
+			ReporterID:  reporterID,
+			Severity:    enums.IncidentSeverityHigh,
+			Type:        "harassment",
+			Description: "Driver was verbally abusive",
+		}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+			t.Errorf("expected the same key on both attempts, got %v", keys)
+		}
+	})
+
 	t.Run("returns error for nil report", func(t *testing.T) {
 		client := createTestClient(t, "http://localhost:8080")
 		_, err := client.ReportIncident(context.Background(), nil)
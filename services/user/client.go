@@ -25,14 +25,38 @@ type Config struct {
 	// BaseURL is the base URL of the User Service (required).
 	BaseURL string
 
-	// Timeout is the request timeout (default: 10s).
+	// Timeout is the total time budget for a call, including retries (default: 10s).
 	Timeout time.Duration
 
+	// RequestTimeout is the timeout for a single attempt (default: a third of Timeout).
+	RequestTimeout time.Duration
+
+	// MaxResponseSize is the maximum size of a response body in bytes (default: 10 MiB).
+	MaxResponseSize int64
+
 	// RetryConfig is the retry configuration.
 	Retry base.RetryConfig
 
 	// CircuitBreaker is the circuit breaker configuration.
 	CircuitBreaker *base.CircuitBreakerConfig
+
+	// Bulkhead limits concurrent calls to the service. Disabled if nil.
+	Bulkhead *base.BulkheadConfig
+
+	// RateLimit limits the rate of requests to the service. Disabled if nil.
+	RateLimit *base.RateLimitConfig
+
+	// LoadBalancer spreads requests across service instances instead of the BaseURL. Disabled if nil.
+	LoadBalancer *base.LoadBalancerConfig
+
+	// Failover sends requests to a secondary deployment while BaseURL is unavailable. Disabled if nil.
+	Failover *base.FailoverConfig
+
+	// Cache caches GET responses following their HTTP caching headers. Disabled if nil.
+	Cache *base.CacheConfig
+
+	// Coalescing shares one response between identical concurrent GET calls. Disabled if nil.
+	Coalescing *base.CoalescingConfig
 }
 
 // DefaultConfig returns a default configuration for the User Service client.
@@ -60,11 +84,18 @@ func NewClient(cfg *Config, logger *logging.Logger) (*Client, error) {
 	}
 
 	baseCfg := &base.Config{
-		BaseURL:        cfg.BaseURL,
-		Timeout:        timeout,
-		RequestTimeout: timeout,
-		Retry:          cfg.Retry,
-		CircuitBreaker: cfg.CircuitBreaker,
+		BaseURL:         cfg.BaseURL,
+		Timeout:         timeout,
+		RequestTimeout:  cfg.RequestTimeout,
+		MaxResponseSize: cfg.MaxResponseSize,
+		Retry:           cfg.Retry,
+		CircuitBreaker:  cfg.CircuitBreaker,
+		Bulkhead:        cfg.Bulkhead,
+		RateLimit:       cfg.RateLimit,
+		LoadBalancer:    cfg.LoadBalancer,
+		Failover:        cfg.Failover,
+		Cache:           cfg.Cache,
+		Coalescing:      cfg.Coalescing,
 	}
 
 	baseClient, err := base.NewClient(baseCfg, logger)
@@ -96,13 +127,7 @@ func (c *Client) GetUser(ctx context.Context, userID ids.UserID) (*User, error)
 		return nil, fmt.Errorf("user ID is required")
 	}
 
-	var user User
-	err := c.client.Get(ctx, fmt.Sprintf("/users/%s", userID)).Decode(&user)
-	if err != nil {
-		return nil, err
-	}
-
-	return &user, nil
+	return base.GetJSON[User](ctx, c.client, "/users/{user_id}", base.Param("user_id", userID))
 }
 
 // GetUserByPhone retrieves a user by their phone number.
@@ -111,15 +136,8 @@ func (c *Client) GetUserByPhone(ctx context.Context, phone contact.PhoneNumber)
 		return nil, fmt.Errorf("phone number is required")
 	}
 
-	var user User
-	err := c.client.Get(ctx, "/users/by-phone").
-		WithQuery("phone", phone.String()).
-		Decode(&user)
-	if err != nil {
-		return nil, err
-	}
-
-	return &user, nil
+	return base.GetJSON[User](ctx, c.client, "/users/by-phone",
+		base.Query("phone", phone.String()))
 }
 
 // VerifyUser marks a user as verified.
@@ -128,16 +146,7 @@ func (c *Client) VerifyUser(ctx context.Context, userID ids.UserID) error {
 		return fmt.Errorf("user ID is required")
 	}
 
-	resp, err := c.client.Post(ctx, fmt.Sprintf("/users/%s/verify", userID), nil).Do()
-	if err != nil {
-		return err
-	}
-
-	if !resp.IsSuccess() {
-		return resp.DecodeError()
-	}
-
-	return nil
+	return base.Exec(ctx, c.client, http.MethodPost, "/users/{user_id}/verify", nil, base.Param("user_id", userID))
 }
 
 // SuspendUserRequest is the request body for suspending a user.
@@ -155,16 +164,12 @@ func (c *Client) SuspendUser(ctx context.Context, userID ids.UserID, reason stri
 	}
 
 	req := SuspendUserRequest{Reason: reason}
-	resp, err := c.client.Post(ctx, fmt.Sprintf("/users/%s/suspend", userID), req).Do()
-	if err != nil {
-		return err
-	}
-
-	if !resp.IsSuccess() {
-		return resp.DecodeError()
-	}
+	return base.Exec(ctx, c.client, http.MethodPost, "/users/{user_id}/suspend", req, base.Param("user_id", userID))
+}
 
-	return nil
+// statusResponse is the response body of the user status endpoint.
+type statusResponse struct {
+	Status enums.UserStatus `json:"status"`
 }
 
 // GetUserStatus retrieves the status of a user.
@@ -173,11 +178,7 @@ func (c *Client) GetUserStatus(ctx context.Context, userID ids.UserID) (enums.Us
 		return "", fmt.Errorf("user ID is required")
 	}
 
-	var response struct {
-		Status enums.UserStatus `json:"status"`
-	}
-
-	err := c.client.Get(ctx, fmt.Sprintf("/users/%s/status", userID)).Decode(&response)
+	response, err := base.GetJSON[statusResponse](ctx, c.client, "/users/{user_id}/status", base.Param("user_id", userID))
 	if err != nil {
 		return "", err
 	}